@@ -1,22 +1,73 @@
 package main
 
 import (
+	"errors"
 	"flag"
-	"log"
+	"fmt"
+	"log/slog"
+	"os"
 
 	"mygo/internal/bootstrap"
 )
 
-var dryRun = flag.Bool("dry-run", false, "预览迁移变更，不实际执行（使用事务回滚）")
+var (
+	dryRun = flag.Bool("dry-run", false, "预览迁移变更，不实际执行（使用事务回滚）")
+	dir    = flag.String("dir", "migrations", "create 生成迁移文件的目录")
+)
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), `用法: migrate [flags] [command] [args]
+
+命令:
+  up                  执行全部待执行迁移（默认）
+  down [n]            回滚最近 n 个迁移，默认 1
+  status              查看各版本执行状态
+  create <name>       生成新的迁移文件
+  baseline <version>  将 version 及之前的迁移标记为已执行，不执行 SQL
+  diff                对比 PO 模型与数据库实际结构，存在差异时以退出码 2 退出
+
+--dry-run 只用于 up、down 与 baseline。
+
+flags:
+`)
+	flag.PrintDefaults()
+}
 
 func main() {
+	flag.Usage = usage
 	flag.Parse()
 
+	// flags 与命令、参数可以交替出现，如 migrate down 2 --dry-run
+	var positional []string
+	args := os.Args[1:]
+	for {
+		flag.CommandLine.Parse(args)
+		if flag.NArg() == 0 {
+			break
+		}
+		positional = append(positional, flag.Arg(0))
+		args = flag.Args()[1:]
+	}
+
+	var command string
+	if len(positional) > 0 {
+		command, positional = positional[0], positional[1:]
+	}
+
 	cfg := bootstrap.MigrateConfig{
-		DryRun: *dryRun,
+		Command: command,
+		Args:    positional,
+		DryRun:  *dryRun,
+		Dir:     *dir,
 	}
 
-	if err := bootstrap.RunMigrate(cfg); err != nil {
-		log.Fatalf("❌ Migration failed: %v", err)
+	err := bootstrap.RunMigrate(cfg)
+	switch {
+	case errors.Is(err, bootstrap.ErrSchemaDrift):
+		slog.Warn("⚠️ Schema drift detected, add a migration to align the database", "error", err)
+		os.Exit(2)
+	case err != nil:
+		slog.Error("❌ Migration failed", "error", err)
+		os.Exit(1)
 	}
 }
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"mygo/internal/bootstrap"
+)
+
+var (
+	dir   = flag.String("dir", "fixtures", "种子文件目录")
+	reset = flag.Bool("reset", false, "加载前按依赖顺序清空全部业务表（release 模式下拒绝执行）")
+)
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), `用法: seed [flags] [set ...]
+
+加载 <dir>/<set>.yaml、.yml 或 .json 中的种子数据，未指定 set 时加载 dev。
+
+flags:
+`)
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Usage = usage
+
+	// flags 与集合名可以交替出现，如 seed dev --reset
+	var sets []string
+	args := os.Args[1:]
+	for {
+		flag.CommandLine.Parse(args)
+		if flag.NArg() == 0 {
+			break
+		}
+		sets = append(sets, flag.Arg(0))
+		args = flag.Args()[1:]
+	}
+	if len(sets) == 0 {
+		sets = []string{"dev"}
+	}
+
+	cfg := bootstrap.SeedConfig{
+		Dir:   *dir,
+		Sets:  sets,
+		Reset: *reset,
+	}
+
+	if err := bootstrap.RunSeed(cfg); err != nil {
+		slog.Error("❌ Seed failed", "error", err)
+		os.Exit(1)
+	}
+}
@@ -1,7 +1,8 @@
 package main
 
 import (
-	"log"
+	"log/slog"
+	"os"
 
 	"mygo/internal/bootstrap"
 )
@@ -10,16 +11,18 @@ func main() {
 	// 1. 初始化应用
 	app, err := bootstrap.NewApp()
 	if err != nil {
-		log.Fatalf("Failed to initialize app: %v", err)
+		slog.Error("Failed to initialize app", "error", err)
+		os.Exit(1)
 	}
 	defer func() {
 		if err := app.Close(); err != nil {
-			log.Printf("Error closing app: %v", err)
+			slog.Error("Error closing app", "error", err)
 		}
 	}()
 
 	// 2. 启动 HTTP 服务器（阻塞）
 	if err := bootstrap.RunHTTPServer(app); err != nil {
-		log.Fatalf("HTTP server error: %v", err)
+		slog.Error("HTTP server error", "error", err)
+		os.Exit(1)
 	}
 }
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+
+	"mygo/internal/bootstrap"
+)
+
+func main() {
+	// 1. 初始化应用
+	app, err := bootstrap.NewApp()
+	if err != nil {
+		slog.Error("Failed to initialize app", "error", err)
+		os.Exit(1)
+	}
+	defer func() {
+		if err := app.Close(); err != nil {
+			slog.Error("Error closing app", "error", err)
+		}
+	}()
+
+	// 2. 启动后台任务处理器（阻塞）
+	if err := bootstrap.RunWorker(app); err != nil {
+		slog.Error("Worker error", "error", err)
+		os.Exit(1)
+	}
+}
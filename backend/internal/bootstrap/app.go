@@ -1,25 +1,54 @@
 package bootstrap
 
 import (
-	"log"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
 
 	"mygo/internal/config"
+	"mygo/internal/idgen"
 	"mygo/internal/infra"
+	"mygo/internal/logging"
+	"mygo/internal/metrics"
 	"mygo/internal/server"
+	"mygo/internal/tracing"
 	userApp "mygo/internal/user/application"
+	userDomain "mygo/internal/user/domain"
+	userBreach "mygo/internal/user/infra/breach"
 	userCache "mygo/internal/user/infra/cache"
+	userHasher "mygo/internal/user/infra/hasher"
+	userJWT "mygo/internal/user/infra/jwt"
 	userPersistence "mygo/internal/user/infra/persistence"
 	userHttp "mygo/internal/user/interfaces/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/extra/redisotel/v9"
 )
 
 // App 应用容器，持有所有依赖
 type App struct {
-	Config    *config.Config
-	Resources *infra.Resources
+	// Config 启动时加载的配置，热更新后不会变化，只用于读取需重启才能生效的字段。
+	// 可热更新的字段（如 auth.registration_mode、log.level）通过 ConfigManager.Current 读取，
+	// 或用 ConfigManager.Subscribe 在变更时更新
+	Config *config.Config
+	// ConfigManager 负责配置热更新
+	ConfigManager *config.Manager
+	Resources     *infra.Resources
+
+	// IDGen 共享的雪花 ID 生成器，供各模块生成业务 ID
+	IDGen     *idgen.Snowflake
+	nodeLease *idgen.NodeLease
+
+	// shutdownTracing 导出剩余 span 并关闭 TracerProvider
+	shutdownTracing func(context.Context) error
 
 	// User 模块
+	UserService *userApp.AppService
 	UserHandler *userHttp.Handler
 }
 
@@ -28,8 +57,29 @@ func NewApp() (*App, error) {
 	app := &App{}
 
 	// 1. 加载配置
-	app.Config = config.Load()
-	log.Printf("Config loaded: port=%s, mode=%s", app.Config.Server.Port, app.Config.Server.Mode)
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	app.Config = cfg
+	app.ConfigManager = config.NewManager(cfg)
+
+	if err := logging.Setup(cfg.Log); err != nil {
+		return nil, err
+	}
+	app.ConfigManager.Subscribe("log", func(cfg *config.Config) {
+		if err := logging.SetLevel(cfg.Log.Level); err != nil {
+			slog.Error("Log level not updated", "error", err)
+		}
+	})
+	slog.Info("Config loaded", "port", cfg.Server.Port, "mode", cfg.Server.Mode, "log_level", cfg.Log.Level)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		return nil, err
+	}
+	app.shutdownTracing = shutdownTracing
+	slog.Info("Tracing initialized", "exporter", cfg.Tracing.Exporter, "sample_ratio", cfg.Tracing.SampleRatio)
 
 	// 设置 gin 模式
 	gin.SetMode(app.Config.Server.Mode)
@@ -40,16 +90,84 @@ func NewApp() (*App, error) {
 		return nil, err
 	}
 	app.Resources = resources
-	log.Println("Infrastructure initialized")
+	if err := app.instrumentResources(); err != nil {
+		return nil, err
+	}
+	slog.Info("Infrastructure initialized")
+
+	// 3. 初始化 ID 生成器
+	if err := app.initIDGen(); err != nil {
+		return nil, err
+	}
 
-	// 3. 初始化各模块
+	app.registerHealthChecks()
+
+	// 4. 初始化各模块
 	if err := app.initUserModule(); err != nil {
 		return nil, err
 	}
 
+	// 5. 初始化管理员账号
+	if err := app.seedAdmin(); err != nil {
+		return nil, err
+	}
+
 	return app, nil
 }
 
+// instrumentResources 注册数据库与 Redis 的指标及链路追踪
+func (app *App) instrumentResources() error {
+	sqlDB, err := app.Resources.DB.DB()
+	if err != nil {
+		return err
+	}
+	if err := metrics.RegisterDB(sqlDB, "postgres"); err != nil {
+		return err
+	}
+	if err := metrics.InstrumentRedis(app.Resources.Redis); err != nil {
+		return err
+	}
+
+	if err := app.Resources.DB.Use(infra.NewTracingPlugin()); err != nil {
+		return fmt.Errorf("gorm tracing: %w", err)
+	}
+	if err := redisotel.InstrumentTracing(app.Resources.Redis); err != nil {
+		return fmt.Errorf("redis tracing: %w", err)
+	}
+	return nil
+}
+
+// initIDGen 初始化雪花 ID 生成器：配置了固定节点 ID 则直接使用，否则从 Redis 租用
+func (app *App) initIDGen() error {
+	nodeID := int64(app.Config.IDGen.NodeID)
+	var opts []idgen.Option
+
+	if nodeID < 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		lease, err := idgen.LeaseNodeID(ctx, app.Resources.Redis, 30*time.Second)
+		if err != nil {
+			return err
+		}
+		app.nodeLease = lease
+		nodeID = lease.NodeID()
+		opts = append(opts, idgen.WithGuard(lease.Err))
+	}
+
+	gen, err := idgen.NewSnowflake(nodeID, opts...)
+	if err != nil {
+		return err
+	}
+	app.IDGen = gen
+	if app.nodeLease != nil {
+		app.nodeLease.OnChange(gen.SetNodeID)
+	}
+
+	slog.Info("ID generator initialized", "node_id", nodeID, "leased", app.nodeLease != nil)
+	return nil
+}
+
 // initUserModule 初始化 User 模块
 func (app *App) initUserModule() error {
 	// Repository
@@ -63,25 +181,301 @@ func (app *App) initUserModule() error {
 		return err
 	}
 
+	tokenRepo, err := userPersistence.NewTokenRepository(app.Resources)
+	if err != nil {
+		return err
+	}
+
+	auditRepo, err := userPersistence.NewAuthEventRepository(app.Resources)
+	if err != nil {
+		return err
+	}
+
+	inviteRepo, err := userPersistence.NewInvitationRepository(app.Resources)
+	if err != nil {
+		return err
+	}
+
+	refreshStore, err := userCache.NewRefreshTokenStore(app.Resources)
+	if err != nil {
+		return err
+	}
+
+	// 可热更新的字段以 ConfigManager 的当前配置为初值，之后由下方的订阅回调更新
+	authCfg := app.ConfigManager.Current().Auth
+	limiterCfg := userCache.DefaultLoginLimiterConfig()
+	limiterCfg.UserMaxFailures = authCfg.LoginMaxFailuresPerUser
+	limiterCfg.IPMaxFailures = authCfg.LoginMaxFailuresPerIP
+	loginLimiter, err := userCache.NewLoginLimiter(app.Resources, limiterCfg)
+	if err != nil {
+		return err
+	}
+
+	settings, err := userSettings(authCfg)
+	if err != nil {
+		return err
+	}
+
+	passwordHasher, err := userHasher.NewFromConfig(userHasher.Config{
+		Algorithm:  authCfg.PasswordHashAlgorithm,
+		BcryptCost: authCfg.BcryptCost,
+		Argon2: userHasher.Argon2Params{
+			Memory:      uint32(authCfg.Argon2Memory),
+			Iterations:  uint32(authCfg.Argon2Iterations),
+			Parallelism: uint8(authCfg.Argon2Parallelism),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	opts := []userApp.Option{
+		userApp.WithIDGenerator(app.IDGen),
+		userApp.WithPasswordHasher(passwordHasher),
+		userApp.WithPasswordPolicy(settings.PasswordPolicy),
+		userApp.WithTokenRepository(tokenRepo),
+		userApp.WithAuthEventRepository(auditRepo),
+		userApp.WithRegistrationMode(settings.RegistrationMode),
+		userApp.WithReservedUsernames(settings.ReservedUsernames),
+		userApp.WithInvitationRepository(inviteRepo),
+		userApp.WithLoginLimiter(loginLimiter),
+		userApp.WithDeletionGracePeriod(time.Duration(authCfg.AccountDeletionGraceDays) * 24 * time.Hour),
+	}
+	if app.Resources.Storage != nil {
+		opts = append(opts, userApp.WithFileStorage(app.Resources.Storage))
+	}
+
+	accessIssuer, err := newAccessTokenIssuer(authCfg)
+	if err != nil {
+		return err
+	}
+	opts = append(opts,
+		userApp.WithJWT(accessIssuer, refreshStore),
+		userApp.WithRefreshTokenTTL(time.Duration(authCfg.JWTRefreshTTLDays)*24*time.Hour),
+	)
+
+	breachChecker, err := newBreachChecker(authCfg)
+	if err != nil {
+		return err
+	}
+	if breachChecker != nil {
+		opts = append(opts, userApp.WithBreachedPasswordChecker(breachChecker))
+	}
+
+	if err := metrics.RegisterCachedGauge("user_sessions_active", "Active login sessions, sampled at most every 30s.", 30*time.Second,
+		func(ctx context.Context) (float64, error) {
+			n, err := sessionCache.Count(ctx)
+			return float64(n), err
+		}); err != nil {
+		return err
+	}
+
 	// Application Service
-	userAppService := userApp.NewAppService(userRepo, sessionCache)
+	app.UserService = userApp.NewAppService(userRepo, sessionCache, opts...)
 
 	// HTTP Handler
-	app.UserHandler = userHttp.NewHandler(userAppService)
+	var handlerOpts []userHttp.HandlerOption
+	if authCfg.CookieSessionsEnabled {
+		cookieCfg, err := newCookieConfig(authCfg)
+		if err != nil {
+			return err
+		}
+		handlerOpts = append(handlerOpts, userHttp.WithCookieSessions(cookieCfg))
+	}
+	app.UserHandler = userHttp.NewHandler(app.UserService, handlerOpts...)
 
-	log.Println("User module initialized")
+	// 配置热更新：注册模式、保留用户名、密码策略与登录锁定阈值
+	app.ConfigManager.Subscribe("user", func(cfg *config.Config) {
+		settings, err := userSettings(cfg.Auth)
+		if err != nil {
+			// 配置已通过校验，这里只是防御
+			slog.Error("User settings not updated", "error", err)
+			return
+		}
+		app.UserService.UpdateSettings(settings)
+		loginLimiter.SetMaxFailures(cfg.Auth.LoginMaxFailuresPerUser, cfg.Auth.LoginMaxFailuresPerIP)
+	})
+
+	slog.Info("User module initialized")
+	return nil
+}
+
+// newAccessTokenIssuer 根据配置加载 JWT 签名密钥，未配置时生成临时密钥
+func newAccessTokenIssuer(cfg config.AuthConfig) (*userJWT.Issuer, error) {
+	var keys []*userJWT.Key
+	for _, entry := range strings.Split(cfg.JWTSigningKeys, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kid, path, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid JWT_SIGNING_KEYS entry %q, expected kid=path", entry)
+		}
+		key, err := userJWT.LoadKeyFile(kid, path)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		key, err := userJWT.GenerateKey()
+		if err != nil {
+			return nil, fmt.Errorf("generate jwt key: %w", err)
+		}
+		keys = append(keys, key)
+		slog.Warn("JWT_SIGNING_KEYS not set, generated a temporary Ed25519 key (access tokens will not survive restarts)")
+	}
+
+	keySet, err := userJWT.NewKeySet(keys[0], keys[1:]...)
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("JWT signing key loaded", "kid", keys[0].ID, "alg", keys[0].Alg, "verify_only_keys", len(keys)-1)
+
+	return userJWT.NewIssuer(keySet, userJWT.Config{
+		Issuer:    cfg.JWTIssuer,
+		Audience:  cfg.JWTAudience,
+		AccessTTL: time.Duration(cfg.JWTAccessTTLMinutes) * time.Minute,
+	})
+}
+
+// newCookieConfig 根据配置创建 Cookie 会话配置，未配置 CSRF 密钥时随机生成
+func newCookieConfig(cfg config.AuthConfig) (userHttp.CookieConfig, error) {
+	cookieCfg := userHttp.DefaultCookieConfig()
+	cookieCfg.Domain = cfg.CookieDomain
+	cookieCfg.Secure = cfg.CookieSecure
+
+	switch strings.ToLower(cfg.CookieSameSite) {
+	case "", "lax":
+		cookieCfg.SameSite = http.SameSiteLaxMode
+	case "strict":
+		cookieCfg.SameSite = http.SameSiteStrictMode
+	case "none":
+		if !cfg.CookieSecure {
+			return cookieCfg, errors.New("COOKIE_SAMESITE=none requires COOKIE_SECURE=true")
+		}
+		cookieCfg.SameSite = http.SameSiteNoneMode
+	default:
+		return cookieCfg, fmt.Errorf("unknown cookie samesite %q", cfg.CookieSameSite)
+	}
+
+	if cfg.CSRFSecret != "" {
+		cookieCfg.CSRFSecret = []byte(cfg.CSRFSecret)
+		return cookieCfg, nil
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return cookieCfg, fmt.Errorf("generate csrf secret: %w", err)
+	}
+	cookieCfg.CSRFSecret = secret
+	slog.Warn("CSRF_SECRET not set, generated a random one (csrf tokens will not survive restarts)")
+	return cookieCfg, nil
+}
+
+// userSettings 由认证配置构造 User 模块可热更新的策略
+func userSettings(cfg config.AuthConfig) (userApp.Settings, error) {
+	mode, err := userDomain.ParseRegistrationMode(cfg.RegistrationMode)
+	if err != nil {
+		return userApp.Settings{}, err
+	}
+	return userApp.Settings{
+		RegistrationMode:  mode,
+		ReservedUsernames: reservedUsernames(cfg.ReservedUsernames),
+		PasswordPolicy: userDomain.PasswordPolicy{
+			MinLength:        cfg.PasswordMinLength,
+			MaxLength:        cfg.PasswordMaxLength,
+			MinCharClasses:   cfg.PasswordMinCharClasses,
+			DisallowUserInfo: cfg.PasswordDisallowUserInfo,
+		},
+	}, nil
+}
+
+// reservedUsernames 解析逗号分隔的保留用户名，为空时使用内置列表
+func reservedUsernames(raw string) []string {
+	if strings.TrimSpace(raw) == "" {
+		return userDomain.DefaultReservedUsernames
+	}
+	return strings.Split(raw, ",")
+}
+
+// newBreachChecker 根据配置创建泄露密码检查器，off 时返回 nil
+func newBreachChecker(cfg config.AuthConfig) (*userBreach.Checker, error) {
+	switch cfg.BreachCheckMode {
+	case "", "off":
+		return nil, nil
+	case "offline":
+		if cfg.BreachListFile != "" {
+			src, err := userBreach.NewFileSource(cfg.BreachListFile)
+			if err != nil {
+				return nil, err
+			}
+			return userBreach.NewChecker(src), nil
+		}
+		src, err := userBreach.NewBundledSource()
+		if err != nil {
+			return nil, err
+		}
+		return userBreach.NewChecker(src), nil
+	case "api":
+		return userBreach.NewChecker(userBreach.NewRangeAPISource(cfg.BreachAPIURL, nil)), nil
+	default:
+		return nil, fmt.Errorf("unknown breach check mode %q", cfg.BreachCheckMode)
+	}
+}
+
+// seedAdmin 根据配置确保管理员账号存在并拥有 admin 角色
+func (app *App) seedAdmin() error {
+	authCfg := app.Config.Auth
+	if authCfg.AdminUsername == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	user, err := app.UserService.SeedAdmin(ctx, userApp.SeedAdminCommand{
+		Username: authCfg.AdminUsername,
+		Email:    authCfg.AdminEmail,
+		Password: authCfg.AdminPassword,
+	})
+	if err != nil {
+		return fmt.Errorf("seed admin: %w", err)
+	}
+
+	slog.Info("Admin seeded", "username", user.Username, "user_id", user.UserID)
 	return nil
 }
 
 // RouterConfig 返回路由配置
 func (app *App) RouterConfig() server.RouterConfig {
-	return server.RouterConfig{
-		UserHandler: app.UserHandler,
+	cfg := server.RouterConfig{
+		ServiceName:    app.Config.Tracing.ServiceName,
+		Health:         app.Resources.Health,
+		UserHandler:    app.UserHandler,
+		TrustedProxies: app.Config.Server.TrustedProxyList(),
+	}
+	if app.Resources.Storage != nil {
+		cfg.UploadDir = app.Resources.Storage.Root()
 	}
+	return cfg
 }
 
 // Close 关闭应用资源
 func (app *App) Close() error {
+	if app.nodeLease != nil {
+		if err := app.nodeLease.Close(); err != nil {
+			slog.Error("Error releasing node id lease", "error", err)
+		}
+	}
+	if app.shutdownTracing != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := app.shutdownTracing(ctx); err != nil {
+			slog.Error("Error flushing traces", "error", err)
+		}
+	}
 	if app.Resources != nil {
 		return app.Resources.Close()
 	}
@@ -0,0 +1,106 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"mygo/internal/infra"
+	"mygo/internal/migration"
+)
+
+// worker 心跳：worker 周期性写入时间戳，server 的健康检查据此判断 worker 是否在运行
+const (
+	workerHeartbeatKey      = "worker:heartbeat"
+	workerHeartbeatInterval = 15 * time.Second
+	// workerHeartbeatTTL 超过该时间没有心跳视为 worker 停止
+	workerHeartbeatTTL = 4 * workerHeartbeatInterval
+)
+
+// registerHealthChecks 注册应用级检查：迁移是否已执行、ID 租约、worker 心跳
+func (app *App) registerHealthChecks() {
+	health := app.Resources.Health
+
+	health.Register(infra.HealthCheck{
+		Name:     "schema",
+		Critical: true,
+		// 迁移只在发布时执行，缓存更久以减少查询
+		CacheTTL: time.Minute,
+		Check: func(ctx context.Context) error {
+			return checkMigrations(ctx, app.Resources.DB)
+		},
+	})
+
+	if app.nodeLease != nil {
+		health.Register(infra.HealthCheck{
+			Name:     "idgen_lease",
+			Critical: true,
+			Check: func(context.Context) error {
+				return app.nodeLease.Err()
+			},
+		})
+	}
+
+	// worker 停止不影响接收请求，只标记为 degraded
+	health.Register(infra.HealthCheck{
+		Name:     "worker",
+		CacheTTL: 10 * time.Second,
+		Check: func(ctx context.Context) error {
+			return checkWorkerHeartbeat(ctx, app.Resources.Redis)
+		},
+	})
+}
+
+// checkMigrations 存在待执行的迁移时返回错误，数据库版本高于当前程序（滚动发布）视为正常
+func checkMigrations(ctx context.Context, db *infra.GormDB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	migrator, err := newMigrator(sqlDB, migration.Options{})
+	if err != nil {
+		return err
+	}
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	var pending []string
+	for _, s := range statuses {
+		if s.Applied == nil {
+			pending = append(pending, fmt.Sprintf("%04d_%s", s.Version, s.Name))
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("pending migrations %s, run cmd/migrate", strings.Join(pending, ", "))
+	}
+	return nil
+}
+
+// checkWorkerHeartbeat 检查最近一次 worker 心跳
+func checkWorkerHeartbeat(ctx context.Context, rdb *infra.RedisClient) error {
+	v, err := rdb.Get(ctx, workerHeartbeatKey).Result()
+	if errors.Is(err, infra.ErrRedisNil) {
+		return fmt.Errorf("no worker heartbeat in the last %s", workerHeartbeatTTL)
+	}
+	if err != nil {
+		return err
+	}
+	sec, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid heartbeat %q", v)
+	}
+	if age := time.Since(time.Unix(sec, 0)); age > workerHeartbeatTTL {
+		return fmt.Errorf("last worker heartbeat %s ago", age.Truncate(time.Second))
+	}
+	return nil
+}
+
+// writeWorkerHeartbeat 写入 worker 心跳，过期时间与判定阈值一致
+func writeWorkerHeartbeat(ctx context.Context, rdb *infra.RedisClient) error {
+	return rdb.Set(ctx, workerHeartbeatKey, time.Now().Unix(), workerHeartbeatTTL).Err()
+}
@@ -2,7 +2,7 @@ package bootstrap
 
 import (
 	"context"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
@@ -18,29 +18,41 @@ type HTTPServerConfig struct {
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 	IdleTimeout  time.Duration
+	// ShutdownTimeout 优雅关闭时等待进行中请求的最长时间
+	ShutdownTimeout time.Duration
 }
 
 // DefaultHTTPServerConfig 返回默认配置
 func DefaultHTTPServerConfig(port string) HTTPServerConfig {
 	return HTTPServerConfig{
-		Port:         port,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+		Port:            port,
+		ReadTimeout:     15 * time.Second,
+		WriteTimeout:    15 * time.Second,
+		IdleTimeout:     60 * time.Second,
+		ShutdownTimeout: 5 * time.Second,
 	}
 }
 
-// RunHTTPServer 启动 HTTP 服务器（阻塞，支持优雅关闭）
+// RunHTTPServer 按应用配置启动 HTTP 服务器（阻塞，支持优雅关闭）
 func RunHTTPServer(app *App) error {
-	cfg := DefaultHTTPServerConfig(app.Config.Server.Port)
-	return RunHTTPServerWithConfig(app, cfg)
+	s := app.Config.Server
+	return RunHTTPServerWithConfig(app, HTTPServerConfig{
+		Port:            s.Port,
+		ReadTimeout:     s.ReadTimeout,
+		WriteTimeout:    s.WriteTimeout,
+		IdleTimeout:     s.IdleTimeout,
+		ShutdownTimeout: s.ShutdownTimeout,
+	})
 }
 
 // RunHTTPServerWithConfig 使用自定义配置启动 HTTP 服务器
 func RunHTTPServerWithConfig(app *App, cfg HTTPServerConfig) error {
 	// 创建路由
-	router := server.NewRouter(app.RouterConfig())
-	log.Println("Router initialized")
+	router, err := server.NewRouter(app.RouterConfig())
+	if err != nil {
+		return err
+	}
+	slog.Info("Router initialized")
 
 	// 创建 HTTP 服务器
 	srv := &http.Server{
@@ -51,11 +63,20 @@ func RunHTTPServerWithConfig(app *App, cfg HTTPServerConfig) error {
 		IdleTimeout:  cfg.IdleTimeout,
 	}
 
+	// 配置热更新（SIGHUP / 配置文件变化）
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	defer stopWatch()
+	go app.ConfigManager.Watch(watchCtx)
+
+	// 指标服务（独立端口）
+	stopMetrics := startMetricsServer(app.Config.Metrics.Addr)
+
 	// 启动服务器（非阻塞）
 	go func() {
-		log.Printf("HTTP server starting on port %s...", cfg.Port)
+		slog.Info("HTTP server starting", "port", cfg.Port)
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("HTTP server failed: %v", err)
+			slog.Error("HTTP server failed", "error", err)
+			os.Exit(1)
 		}
 	}()
 
@@ -63,16 +84,19 @@ func RunHTTPServerWithConfig(app *App, cfg HTTPServerConfig) error {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
-	log.Println("Shutting down HTTP server...")
+	slog.Info("Shutting down HTTP server")
+	// readiness 立即失败，负载均衡停止分配新请求，进行中的请求由 Shutdown 等待完成
+	app.Resources.Health.SetDraining()
 
-	// 优雅关闭（5 秒超时）
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	// 优雅关闭
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
 	defer cancel()
 
 	if err := srv.Shutdown(ctx); err != nil {
 		return err
 	}
+	stopMetrics(ctx)
 
-	log.Println("HTTP server stopped gracefully")
+	slog.Info("HTTP server stopped gracefully")
 	return nil
 }
@@ -0,0 +1,39 @@
+package bootstrap
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"mygo/internal/metrics"
+)
+
+// startMetricsServer 在 addr 上暴露 /metrics（非阻塞），addr 为空时不启动。
+// 返回的函数用于优雅关闭，未启动时为空操作。
+func startMetricsServer(addr string) (shutdown func(context.Context)) {
+	if addr == "" {
+		return func(context.Context) {}
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		slog.Info("Metrics server starting", "addr", addr)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			slog.Error("Metrics server failed", "error", err)
+		}
+	}()
+
+	return func(ctx context.Context) {
+		if err := srv.Shutdown(ctx); err != nil {
+			slog.Error("Metrics server shutdown", "error", err)
+		}
+	}
+}
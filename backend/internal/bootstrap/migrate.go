@@ -1,91 +1,298 @@
 package bootstrap
 
 import (
+	"context"
+	"database/sql"
 	"errors"
-	"log"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
 
 	"mygo/internal/config"
 	"mygo/internal/infra"
+	"mygo/internal/logging"
+	"mygo/internal/migration"
 	userPersistence "mygo/internal/user/infra/persistence"
+	"mygo/migrations"
 
 	"gorm.io/gorm"
 )
 
 // MigrateConfig 迁移配置
 type MigrateConfig struct {
-	DryRun bool // 预览模式，不实际执行
+	Command string   // up | down | status | create | baseline | diff，为空时执行 up
+	Args    []string // 命令参数：down 的回滚个数、create 的名称、baseline 的版本号
+	DryRun  bool     // 预览模式，在事务中执行后回滚，不实际改动数据库
+	Dir     string   // create 生成文件的目录
 }
 
-// 所有需要迁移的 PO 模型
+// 所有持久化的 PO 模型。表结构由 migrations 目录下的 SQL 迁移维护，
+// 这里的模型用于 diff 命令与数据库实际结构对比
 var migrateModels = []any{
 	// User 模块
 	&userPersistence.UserPO{},
+	&userPersistence.UserRolePO{},
+	&userPersistence.PersonalAccessTokenPO{},
+	&userPersistence.AuthEventPO{},
+	&userPersistence.InvitationPO{},
 }
 
+// baselineSteps 标记基线前对旧版本 AutoMigrate 建立的数据库执行的准备步骤，
+// 处理之后的 SQL 迁移无法完成的数据回填（如 0008 的规范形式列）。步骤需可重复执行，在同一个事务中执行
+var baselineSteps = []struct {
+	name string
+	run  func(context.Context, *gorm.DB) error
+}{
+	{"users canonical identity", userPersistence.PrepareCanonicalIdentity},
+}
+
+// ErrSchemaDrift diff 发现模型与数据库结构不一致，与执行失败区分，便于 CI 判断
+var ErrSchemaDrift = errors.New("schema drift detected")
+
 // errDryRunRollback 用于 dry-run 模式触发回滚
 var errDryRunRollback = errors.New("dry-run: rollback")
 
-// RunMigrate 执行数据库迁移
+// prepareBaseline 执行 baselineSteps，dry-run 时回滚
+func prepareBaseline(ctx context.Context, db *gorm.DB, dryRun bool) error {
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, step := range baselineSteps {
+			if err := step.run(ctx, tx); err != nil {
+				return fmt.Errorf("%s: %w", step.name, err)
+			}
+		}
+		if dryRun {
+			return errDryRunRollback
+		}
+		return nil
+	})
+	if errors.Is(err, errDryRunRollback) {
+		return nil
+	}
+	return err
+}
+
+// maxMigrateArgs 各命令最多接受的参数个数
+var maxMigrateArgs = map[string]int{
+	"":         0,
+	"up":       0,
+	"down":     1,
+	"baseline": 1,
+	"status":   0,
+	"diff":     0,
+}
+
+// dryRunCommands 支持 --dry-run 的命令，其余命令不修改数据库或不使用事务，传入时报错而不是静默忽略
+var dryRunCommands = map[string]bool{"": true, "up": true, "down": true, "baseline": true}
+
+// validateMigrateArgs 拒绝多余参数，避免写错的 flag（如 "-dryrun"）被当成参数而静默忽略
+func validateMigrateArgs(cfg MigrateConfig) error {
+	if cfg.DryRun && !dryRunCommands[cfg.Command] {
+		return fmt.Errorf("%s: --dry-run is not supported", cfg.Command)
+	}
+	if cfg.Command == "create" {
+		return nil
+	}
+	limit, ok := maxMigrateArgs[cfg.Command]
+	if !ok {
+		return fmt.Errorf("unknown command %q", cfg.Command)
+	}
+	for _, arg := range cfg.Args {
+		if strings.HasPrefix(arg, "-") {
+			return fmt.Errorf("%s: unexpected argument %q", cfg.Command, arg)
+		}
+	}
+	if len(cfg.Args) > limit {
+		return fmt.Errorf("%s: unexpected arguments %q", cfg.Command, cfg.Args[limit:])
+	}
+	return nil
+}
+
+// RunMigrate 执行迁移命令
 func RunMigrate(cfg MigrateConfig) error {
-	if cfg.DryRun {
-		log.Println("🔍 Dry-run mode: 将在事务中执行迁移，完成后回滚")
+	if err := validateMigrateArgs(cfg); err != nil {
+		return err
+	}
+	if cfg.Command == "create" {
+		return createMigration(cfg)
 	}
-	log.Println("🚀 Starting database migration...")
 
 	// 1. 加载配置
-	appCfg := config.Load()
-
-	// 2. 连接数据库
-	db, err := infra.NewGormPG(appCfg.Infra.PGDSN)
+	appCfg, err := config.Load()
 	if err != nil {
 		return err
 	}
-
-	// 开启 Debug 模式以打印 SQL
-	if cfg.DryRun {
-		db = db.Debug()
+	if err := logging.Setup(appCfg.Log); err != nil {
+		return err
 	}
 
+	// 2. 连接数据库
+	db, err := infra.NewGormPG(appCfg.Infra.DB)
+	if err != nil {
+		return err
+	}
 	sqlDB, _ := db.DB()
 	defer sqlDB.Close()
 
-	log.Println("✅ Database connected")
+	migrator, err := newMigrator(sqlDB, migration.Options{DryRun: cfg.DryRun})
+	if err != nil {
+		return err
+	}
 
-	// 3. 执行迁移
-	if cfg.DryRun {
-		// Dry-run: 在事务中执行后回滚
-		err = db.Transaction(func(tx *gorm.DB) error {
-			if err := tx.AutoMigrate(migrateModels...); err != nil {
-				return err
+	// 3. 执行命令
+	ctx := context.Background()
+	switch cfg.Command {
+	case "", "up":
+		return runMigrations(cfg, "up", func() ([]migration.Migration, error) {
+			return migrator.Up(ctx)
+		})
+	case "down":
+		n := 1
+		if len(cfg.Args) > 0 {
+			if n, err = strconv.Atoi(cfg.Args[0]); err != nil || n <= 0 {
+				return fmt.Errorf("invalid rollback count %q", cfg.Args[0])
 			}
-			// 迁移成功，但返回错误以触发回滚
-			return errDryRunRollback
+		}
+		return runMigrations(cfg, "down", func() ([]migration.Migration, error) {
+			return migrator.Down(ctx, n)
+		})
+	case "baseline":
+		if len(cfg.Args) == 0 {
+			return errors.New("baseline requires a version, e.g. migrate baseline 1")
+		}
+		version, err := strconv.ParseInt(cfg.Args[0], 10, 64)
+		if err != nil || !slices.ContainsFunc(migrator.Migrations(), func(m migration.Migration) bool { return m.Version == version }) {
+			return fmt.Errorf("invalid version %q", cfg.Args[0])
+		}
+		return runMigrations(cfg, "baseline", func() ([]migration.Migration, error) {
+			if err := prepareBaseline(ctx, db, cfg.DryRun); err != nil {
+				return nil, err
+			}
+			return migrator.Baseline(ctx, version)
 		})
+	case "status":
+		return printMigrationStatus(ctx, migrator)
+	case "diff":
+		return printSchemaDrift(ctx, db)
+	default:
+		return fmt.Errorf("unknown command %q", cfg.Command)
+	}
+}
+
+// runMigrations 执行 up / down / baseline 并输出结果
+func runMigrations(cfg MigrateConfig, command string, run func() ([]migration.Migration, error)) error {
+	if cfg.DryRun {
+		slog.Info("🔍 Dry-run mode: 将在事务中执行迁移，完成后回滚")
+	}
+	slog.Info("🚀 Starting database migration", "command", command)
+
+	done, err := run()
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, len(done))
+	for i, m := range done {
+		names[i] = m.String()
+	}
+	switch {
+	case cfg.DryRun:
+		slog.Info("✅ Dry-run completed! 事务已回滚，数据库未做任何改动", "migrations", names)
+	case len(done) == 0:
+		slog.Info("✅ Nothing to do, database is up to date")
+	default:
+		slog.Info("✅ Migration completed!", "command", command, "migrations", names)
+	}
+	return nil
+}
+
+// printMigrationStatus 以表格输出每个版本的执行状态
+func printMigrationStatus(ctx context.Context, migrator *migration.Migrator) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
 
-		if errors.Is(err, errDryRunRollback) {
-			log.Println("✅ Dry-run completed! 事务已回滚，数据库未做任何改动")
-			err = nil
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, s := range statuses {
+		state, appliedAt := "pending", "-"
+		if s.Applied != nil {
+			appliedAt = s.Applied.AppliedAt.Local().Format("2006-01-02 15:04:05")
+			state = "applied"
+			if s.Applied.Baseline {
+				state = "baseline"
+			}
+		}
+		switch {
+		case s.Missing:
+			state = "missing"
+		case s.Modified:
+			state = "modified"
 		}
-	} else {
-		err = db.AutoMigrate(migrateModels...)
+		fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", s.Version, s.Name, state, appliedAt)
 	}
+	return w.Flush()
+}
 
+// printSchemaDrift 输出迁移模型与数据库实际结构的差异，存在差异时返回错误
+func printSchemaDrift(ctx context.Context, db *infra.GormDB) error {
+	drifts, err := migration.Diff(ctx, db, migrateModels)
 	if err != nil {
 		return err
 	}
+	if len(drifts) == 0 {
+		slog.Info("✅ No schema drift", "models", len(migrateModels))
+		return nil
+	}
 
-	if !cfg.DryRun {
-		log.Printf("✅ Migration completed! Migrated %d model(s)", len(migrateModels))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TABLE\tDRIFT\tOBJECT\tMODEL\tDATABASE")
+	for _, d := range drifts {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", d.Table, d.Kind, orDash(d.Object), orDash(d.Want), orDash(d.Got))
 	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: %d differences", ErrSchemaDrift, len(drifts))
+}
 
-	log.Println("📋 Registered models:")
-	for _, m := range migrateModels {
-		log.Printf("   - %T", m)
+func orDash(s string) string {
+	if s == "" {
+		return "-"
 	}
+	return s
+}
 
+// createMigration 生成新的迁移文件，不需要连接数据库
+func createMigration(cfg MigrateConfig) error {
+	if len(cfg.Args) == 0 {
+		return errors.New("create requires a name, e.g. migrate create add_user_phone")
+	}
+	dir := cfg.Dir
+	if dir == "" {
+		dir = "migrations"
+	}
+	up, down, err := migration.Create(dir, strings.Join(cfg.Args, "_"))
+	if err != nil {
+		return err
+	}
+	slog.Info("📝 Created migration", "up", up, "down", down)
 	return nil
 }
 
+// newMigrator 基于内嵌的迁移文件创建 Migrator
+func newMigrator(db *sql.DB, opts migration.Options) (*migration.Migrator, error) {
+	list, err := migration.Load(migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+	return migration.New(db, list, opts), nil
+}
+
 // GetMigrateModels 返回所有迁移模型（供外部使用）
 func GetMigrateModels() []any {
 	return migrateModels
@@ -0,0 +1,41 @@
+package bootstrap
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateMigrateArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     MigrateConfig
+		wantErr string
+	}{
+		{"default up", MigrateConfig{}, ""},
+		{"up dry-run", MigrateConfig{Command: "up", DryRun: true}, ""},
+		{"down with count", MigrateConfig{Command: "down", Args: []string{"2"}, DryRun: true}, ""},
+		{"baseline with version", MigrateConfig{Command: "baseline", Args: []string{"1"}}, ""},
+		{"create joins words", MigrateConfig{Command: "create", Args: []string{"add", "user", "phone"}}, ""},
+		{"unknown command", MigrateConfig{Command: "redo"}, `unknown command "redo"`},
+		{"extra argument", MigrateConfig{Command: "down", Args: []string{"2", "3"}}, "unexpected arguments"},
+		{"status takes no arguments", MigrateConfig{Command: "status", Args: []string{"all"}}, "unexpected arguments"},
+		{"flag-like argument", MigrateConfig{Command: "down", Args: []string{"-dryrun"}}, `unexpected argument "-dryrun"`},
+		{"diff rejects dry-run", MigrateConfig{Command: "diff", DryRun: true}, "--dry-run is not supported"},
+		{"status rejects dry-run", MigrateConfig{Command: "status", DryRun: true}, "--dry-run is not supported"},
+		{"create rejects dry-run", MigrateConfig{Command: "create", Args: []string{"x"}, DryRun: true}, "--dry-run is not supported"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMigrateArgs(tt.cfg)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateMigrateArgs() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("validateMigrateArgs() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"mygo/internal/infra"
+	userApp "mygo/internal/user/application"
+	userDomain "mygo/internal/user/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/goccy/go-yaml"
+	"gorm.io/gorm"
+)
+
+// SeedConfig 种子数据配置
+type SeedConfig struct {
+	Dir   string   // 种子文件目录
+	Sets  []string // 种子集合名，对应 Dir 下的 <name>.yaml / <name>.yml / <name>.json
+	Reset bool     // 加载前清空全部业务表
+}
+
+// fixtureSet 一个种子集合
+type fixtureSet struct {
+	Users []userFixture `yaml:"users" json:"users"`
+}
+
+// userFixture 种子用户，通过 UserService 注册，密码哈希与注册校验照常生效
+type userFixture struct {
+	Username string   `yaml:"username" json:"username"`
+	Email    string   `yaml:"email" json:"email"`
+	Password string   `yaml:"password" json:"password"`
+	Roles    []string `yaml:"roles" json:"roles"`
+}
+
+// RunSeed 加载种子数据。已存在的用户只补齐角色，重复执行是幂等的
+func RunSeed(cfg SeedConfig) error {
+	// 1. 先读取全部种子文件，格式错误时不连接数据库
+	sets := make([]*fixtureSet, len(cfg.Sets))
+	for i, name := range cfg.Sets {
+		set, err := loadFixtureSet(cfg.Dir, name)
+		if err != nil {
+			return err
+		}
+		sets[i] = set
+	}
+
+	// 2. 初始化应用
+	app, err := NewApp()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := app.Close(); err != nil {
+			slog.Error("Error closing app", "error", err)
+		}
+	}()
+
+	ctx := context.Background()
+	if err := checkMigrations(ctx, app.Resources.DB); err != nil {
+		return err
+	}
+
+	// 3. 清空业务表
+	if cfg.Reset {
+		if app.Config.Server.Mode == gin.ReleaseMode {
+			return errors.New("refusing to reset database in release mode")
+		}
+		tables, err := resetTables(ctx, app.Resources.DB)
+		if err != nil {
+			return err
+		}
+		slog.Info("🧹 Tables truncated", "tables", tables)
+
+		// 配置中的管理员随表一起被清空，重新初始化
+		if err := app.seedAdmin(); err != nil {
+			return err
+		}
+	}
+
+	// 4. 加载种子数据
+	for i, set := range sets {
+		created, existing, err := seedUsers(ctx, app.UserService, set.Users)
+		if err != nil {
+			return fmt.Errorf("fixture set %s: %w", cfg.Sets[i], err)
+		}
+		slog.Info("🌱 Fixture set loaded", "set", cfg.Sets[i], "users_created", created, "users_existing", existing)
+	}
+	return nil
+}
+
+// seedUsers 依次创建种子用户，返回新建与已存在的数量
+func seedUsers(ctx context.Context, svc *userApp.AppService, users []userFixture) (created, existing int, err error) {
+	for _, f := range users {
+		roles := make([]userDomain.Role, 0, len(f.Roles))
+		for _, r := range f.Roles {
+			role, err := userDomain.ParseRole(r)
+			if err != nil {
+				return created, existing, fmt.Errorf("user %q: %w", f.Username, err)
+			}
+			roles = append(roles, role)
+		}
+
+		_, isNew, err := svc.SeedUser(ctx, userApp.SeedUserCommand{
+			Username: f.Username,
+			Email:    f.Email,
+			Password: f.Password,
+			Roles:    roles,
+		})
+		if err != nil {
+			return created, existing, err
+		}
+		if isNew {
+			created++
+		} else {
+			existing++
+		}
+	}
+	return created, existing, nil
+}
+
+// loadFixtureSet 读取 dir 下名为 name 的种子文件，按扩展名解析 YAML 或 JSON
+func loadFixtureSet(dir, name string) (*fixtureSet, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return nil, fmt.Errorf("invalid fixture set name %q", name)
+	}
+
+	for _, ext := range []string{".yaml", ".yml", ".json"} {
+		path := filepath.Join(dir, name+ext)
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		set := &fixtureSet{}
+		if ext == ".json" {
+			dec := json.NewDecoder(bytes.NewReader(data))
+			dec.DisallowUnknownFields()
+			err = dec.Decode(set)
+		} else {
+			err = yaml.UnmarshalWithOptions(data, set, yaml.Strict())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+		return set, nil
+	}
+	return nil, fmt.Errorf("fixture set %q not found in %s", name, dir)
+}
+
+// resetTables 在一条 TRUNCATE 中按依赖顺序（被引用的表在后）清空全部迁移模型对应的表，
+// 返回清空的表名。schema_migrations 不受影响
+func resetTables(ctx context.Context, db *infra.GormDB) ([]string, error) {
+	tables := make([]string, 0, len(migrateModels))
+	for i := len(migrateModels) - 1; i >= 0; i-- {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(migrateModels[i]); err != nil {
+			return nil, fmt.Errorf("parse %T: %w", migrateModels[i], err)
+		}
+		tables = append(tables, stmt.Schema.Table)
+	}
+
+	// 存在外键引用的表必须在同一条 TRUNCATE 中清空
+	if err := db.WithContext(ctx).Exec("TRUNCATE TABLE " + strings.Join(tables, ", ") + " RESTART IDENTITY").Error; err != nil {
+		return nil, fmt.Errorf("truncate: %w", err)
+	}
+	return tables, nil
+}
@@ -2,22 +2,31 @@ package bootstrap
 
 import (
 	"context"
-	"log"
+	"log/slog"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
+	"time"
+
+	"mygo/internal/metrics"
 )
 
 // WorkerConfig 后台任务配置
 type WorkerConfig struct {
 	// 并发 Worker 数量
 	Concurrency int
+
+	// 清理注销宽限期已过的账号的周期
+	AccountPurgeInterval time.Duration
 }
 
 // DefaultWorkerConfig 返回默认配置
 func DefaultWorkerConfig() WorkerConfig {
 	return WorkerConfig{
 		Concurrency: 4,
+
+		AccountPurgeInterval: time.Hour,
 	}
 }
 
@@ -32,14 +41,38 @@ func RunWorkerWithConfig(app *App, cfg WorkerConfig) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	log.Printf("Worker starting with concurrency=%d...", cfg.Concurrency)
+	slog.Info("Worker starting", "concurrency", cfg.Concurrency)
+
+	// 配置热更新（SIGHUP / 配置文件变化）
+	go app.ConfigManager.Watch(ctx)
+
+	// 指标服务（独立端口）
+	stopMetrics := startMetricsServer(app.Config.Metrics.Addr)
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		stopMetrics(shutdownCtx)
+	}()
+
+	var wg sync.WaitGroup
+
+	// 心跳，供 server 的健康检查判断 worker 是否在运行
+	runPeriodic(ctx, &wg, "heartbeat", workerHeartbeatInterval, func(ctx context.Context) error {
+		return writeWorkerHeartbeat(ctx, app.Resources.Redis)
+	})
 
-	// TODO: 启动后台任务处理
+	// TODO: 其他后台任务
 	// 示例任务类型：
 	// - 静态内容预处理
 	// - 站点缓存刷新
-	// - 周期性清理任务
 	// - 异步通知或回调
+	runPeriodic(ctx, &wg, "account purge", cfg.AccountPurgeInterval, func(ctx context.Context) error {
+		n, err := app.UserService.PurgeDeletedUsers(ctx, time.Now())
+		if n > 0 {
+			slog.Info("Purged deleted accounts", "count", n)
+		}
+		return err
+	})
 
 	// 等待中断信号
 	quit := make(chan os.Signal, 1)
@@ -47,13 +80,50 @@ func RunWorkerWithConfig(app *App, cfg WorkerConfig) error {
 
 	select {
 	case <-quit:
-		log.Println("Shutting down worker...")
+		slog.Info("Shutting down worker")
 		cancel()
 	case <-ctx.Done():
 	}
 
-	// TODO: 等待所有任务完成
+	// 等待进行中的任务完成
+	wg.Wait()
 
-	log.Println("Worker stopped gracefully")
+	slog.Info("Worker stopped gracefully")
 	return nil
 }
+
+// runPeriodic 启动周期任务：立即执行一次，之后每 interval 执行一次，ctx 取消时退出。
+// interval 不大于 0 时不启动。
+func runPeriodic(ctx context.Context, wg *sync.WaitGroup, name string, interval time.Duration, job func(context.Context) error) {
+	if interval <= 0 {
+		slog.Info("Worker job disabled", "job", name)
+		return
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			start := time.Now()
+			err := job(ctx)
+			if ctx.Err() != nil {
+				// 退出过程中被取消的执行不计入指标
+				return
+			}
+			metrics.ObserveJob(name, start, err)
+			if err != nil {
+				slog.Error("Worker job failed", "job", name, "error", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
@@ -0,0 +1,165 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// applyEnv 用环境变量覆盖配置，返回全部解析错误
+func (c *Config) applyEnv() error {
+	e := &envReader{}
+
+	e.str("PORT", &c.Server.Port)
+	e.str("GIN_MODE", &c.Server.Mode)
+	e.duration("HTTP_READ_TIMEOUT", &c.Server.ReadTimeout)
+	e.duration("HTTP_WRITE_TIMEOUT", &c.Server.WriteTimeout)
+	e.duration("HTTP_IDLE_TIMEOUT", &c.Server.IdleTimeout)
+	e.duration("HTTP_SHUTDOWN_TIMEOUT", &c.Server.ShutdownTimeout)
+	e.str("TRUSTED_PROXIES", &c.Server.TrustedProxies)
+
+	e.str("LOG_LEVEL", &c.Log.Level)
+	e.str("LOG_FORMAT", &c.Log.Format)
+
+	e.str("METRICS_ADDR", &c.Metrics.Addr)
+
+	e.str("TRACING_EXPORTER", &c.Tracing.Exporter)
+	e.str("TRACING_ENDPOINT", &c.Tracing.Endpoint)
+	e.float("TRACING_SAMPLE_RATIO", &c.Tracing.SampleRatio)
+	e.str("TRACING_SERVICE_NAME", &c.Tracing.ServiceName)
+
+	db := &c.Infra.DB
+	e.secret("DATABASE_URL", &db.DSN)
+	e.int("DB_MAX_OPEN_CONNS", &db.MaxOpenConns)
+	e.int("DB_MAX_IDLE_CONNS", &db.MaxIdleConns)
+	e.duration("DB_CONN_MAX_LIFETIME", &db.ConnMaxLifetime)
+	e.duration("DB_CONN_MAX_IDLE_TIME", &db.ConnMaxIdleTime)
+	e.duration("DB_CONNECT_TIMEOUT", &db.ConnectTimeout)
+
+	rds := &c.Infra.Redis
+	e.secret("REDIS_URL", &rds.URL)
+	e.int("REDIS_POOL_SIZE", &rds.PoolSize)
+	e.int("REDIS_MIN_IDLE_CONNS", &rds.MinIdleConns)
+	e.duration("REDIS_DIAL_TIMEOUT", &rds.DialTimeout)
+	e.duration("REDIS_READ_TIMEOUT", &rds.ReadTimeout)
+	e.duration("REDIS_WRITE_TIMEOUT", &rds.WriteTimeout)
+
+	e.str("STORAGE_DIR", &c.Infra.Storage.Dir)
+	e.str("STORAGE_BASE_URL", &c.Infra.Storage.BaseURL)
+
+	auth := &c.Auth
+	e.str("REGISTRATION_MODE", &auth.RegistrationMode)
+	e.str("RESERVED_USERNAMES", &auth.ReservedUsernames)
+
+	e.bool("COOKIE_SESSIONS_ENABLED", &auth.CookieSessionsEnabled)
+	e.str("COOKIE_DOMAIN", &auth.CookieDomain)
+	e.bool("COOKIE_SECURE", &auth.CookieSecure)
+	e.str("COOKIE_SAMESITE", &auth.CookieSameSite)
+	e.secret("CSRF_SECRET", &auth.CSRFSecret)
+
+	e.str("JWT_SIGNING_KEYS", &auth.JWTSigningKeys)
+	e.str("JWT_ISSUER", &auth.JWTIssuer)
+	e.str("JWT_AUDIENCE", &auth.JWTAudience)
+	e.int("JWT_ACCESS_TTL_MINUTES", &auth.JWTAccessTTLMinutes)
+	e.int("JWT_REFRESH_TTL_DAYS", &auth.JWTRefreshTTLDays)
+
+	e.str("ADMIN_USERNAME", &auth.AdminUsername)
+	e.str("ADMIN_EMAIL", &auth.AdminEmail)
+	e.secret("ADMIN_PASSWORD", &auth.AdminPassword)
+
+	e.int("LOGIN_MAX_FAILURES_PER_USER", &auth.LoginMaxFailuresPerUser)
+	e.int("LOGIN_MAX_FAILURES_PER_IP", &auth.LoginMaxFailuresPerIP)
+
+	e.str("PASSWORD_HASH_ALGORITHM", &auth.PasswordHashAlgorithm)
+	e.int("BCRYPT_COST", &auth.BcryptCost)
+	e.int("ARGON2_MEMORY_KIB", &auth.Argon2Memory)
+	e.int("ARGON2_ITERATIONS", &auth.Argon2Iterations)
+	e.int("ARGON2_PARALLELISM", &auth.Argon2Parallelism)
+
+	e.int("PASSWORD_MIN_LENGTH", &auth.PasswordMinLength)
+	e.int("PASSWORD_MAX_LENGTH", &auth.PasswordMaxLength)
+	e.int("PASSWORD_MIN_CHAR_CLASSES", &auth.PasswordMinCharClasses)
+	e.bool("PASSWORD_DISALLOW_USER_INFO", &auth.PasswordDisallowUserInfo)
+
+	e.str("BREACH_CHECK_MODE", &auth.BreachCheckMode)
+	e.str("BREACH_LIST_FILE", &auth.BreachListFile)
+	e.str("BREACH_API_URL", &auth.BreachAPIURL)
+
+	e.int("ACCOUNT_DELETION_GRACE_DAYS", &auth.AccountDeletionGraceDays)
+
+	e.int("IDGEN_NODE_ID", &c.IDGen.NodeID)
+
+	return errors.Join(e.errs...)
+}
+
+// envReader 读取环境变量并收集解析错误；未设置或为空的变量不覆盖原值
+type envReader struct {
+	errs []error
+}
+
+func (e *envReader) str(key string, dst *string) {
+	if v := os.Getenv(key); v != "" {
+		*dst = v
+	}
+}
+
+// secret 读取敏感配置：<KEY>_FILE 指向的文件内容（去除首尾空白）优先于 <KEY> 本身
+func (e *envReader) secret(key string, dst *string) {
+	if path := os.Getenv(key + "_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			e.errs = append(e.errs, fmt.Errorf("%s_FILE: %w", key, err))
+			return
+		}
+		*dst = strings.TrimSpace(string(data))
+		return
+	}
+	e.str(key, dst)
+}
+
+func (e *envReader) int(key string, dst *int) {
+	if v := os.Getenv(key); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			e.errs = append(e.errs, fmt.Errorf("%s: %q is not an integer", key, v))
+			return
+		}
+		*dst = n
+	}
+}
+
+func (e *envReader) float(key string, dst *float64) {
+	if v := os.Getenv(key); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			e.errs = append(e.errs, fmt.Errorf("%s: %q is not a number", key, v))
+			return
+		}
+		*dst = f
+	}
+}
+
+func (e *envReader) bool(key string, dst *bool) {
+	if v := os.Getenv(key); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			e.errs = append(e.errs, fmt.Errorf("%s: %q is not a boolean", key, v))
+			return
+		}
+		*dst = b
+	}
+}
+
+func (e *envReader) duration(key string, dst *time.Duration) {
+	if v := os.Getenv(key); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			e.errs = append(e.errs, fmt.Errorf("%s: %q is not a duration (e.g. 30s, 5m)", key, v))
+			return
+		}
+		*dst = d
+	}
+}
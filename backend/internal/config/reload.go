@@ -0,0 +1,264 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadable 可热更新的字段（YAML 路径）。其余字段变化时只记录日志，需重启才能生效。
+var reloadable = map[string]bool{
+	"log.level":                        true,
+	"auth.registration_mode":           true,
+	"auth.reserved_usernames":          true,
+	"auth.login_max_failures_per_user": true,
+	"auth.login_max_failures_per_ip":   true,
+	"auth.password_min_length":         true,
+	"auth.password_max_length":         true,
+	"auth.password_min_char_classes":   true,
+	"auth.password_disallow_user_info": true,
+}
+
+// sensitive 日志中不输出取值的字段
+var sensitive = map[string]bool{
+	"database.dsn":        true,
+	"redis.url":           true,
+	"auth.csrf_secret":    true,
+	"auth.admin_password": true,
+}
+
+// reloadDebounce 文件变更事件的合并窗口，编辑器保存通常会触发多个事件
+const reloadDebounce = 500 * time.Millisecond
+
+// Manager 持有当前配置，在收到 SIGHUP 或配置文件变化时重新加载。
+//
+// 重新加载的配置先完整校验，失败时保留旧配置；通过后只替换可热更新的字段，
+// 整体原子替换并依次通知订阅者。
+type Manager struct {
+	current atomic.Pointer[Config]
+	load    func() (*Config, error)
+
+	mu          sync.Mutex // 串行化配置的比较与替换，保护 subscribers；通知订阅者时不持有
+	subscribers []subscriber
+}
+
+type subscriber struct {
+	name string
+	fn   func(*Config)
+}
+
+// NewManager 以已加载的配置创建 Manager
+func NewManager(cfg *Config) *Manager {
+	m := &Manager{load: Load}
+	m.current.Store(cfg)
+	return m
+}
+
+// Current 返回当前配置，调用方不得修改
+func (m *Manager) Current() *Config {
+	return m.current.Load()
+}
+
+// Subscribe 注册配置变更回调，仅在可热更新字段发生变化后调用。
+// 回调在 Reload 的调用方 goroutine 中依次执行，应尽快返回；回调中可以调用 Subscribe 与 Reload。
+func (m *Manager) Subscribe(name string, fn func(*Config)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.subscribers = append(m.subscribers, subscriber{name: name, fn: fn})
+}
+
+// Reload 重新加载并应用配置，校验失败时返回错误且不做任何修改
+func (m *Manager) Reload() error {
+	subscribers, err := m.apply()
+	if err != nil {
+		return err
+	}
+
+	// 在锁外通知，并发 Reload 时回调可能乱序执行，因此总是传入最新配置
+	for _, s := range subscribers {
+		s.fn(m.Current())
+	}
+	return nil
+}
+
+// apply 加载配置并替换可热更新的字段，有变化时返回此刻订阅者的副本
+func (m *Manager) apply() ([]subscriber, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	next, err := m.load()
+	if err != nil {
+		return nil, err
+	}
+
+	old := m.Current()
+	merged := *old
+	var applied, ignored []string
+	diffConfig("", reflect.ValueOf(old).Elem(), reflect.ValueOf(next).Elem(), reflect.ValueOf(&merged).Elem(),
+		func(path string, from, to, target reflect.Value) {
+			if !reloadable[path] {
+				ignored = append(ignored, path)
+				return
+			}
+			target.Set(to)
+			applied = append(applied, fmt.Sprintf("%s: %s -> %s", path, formatValue(path, from), formatValue(path, to)))
+		})
+
+	if len(ignored) > 0 {
+		slog.Warn("Config reload: changes require restart", "fields", ignored)
+	}
+	if len(applied) == 0 {
+		slog.Info("Config reload: no reloadable changes")
+		return nil, nil
+	}
+
+	m.current.Store(&merged)
+	slog.Info("Config reloaded", "changes", applied)
+
+	return slices.Clone(m.subscribers), nil
+}
+
+// Watch 监听 SIGHUP 与 CONFIG_FILE 的变化并触发 Reload，ctx 取消时返回
+func (m *Manager) Watch(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var events <-chan fsnotify.Event
+	var errs <-chan error
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		w, err := watchFile(path)
+		if err != nil {
+			slog.Warn("Config watch disabled", "error", err)
+		} else {
+			defer w.Close()
+			events, errs = filterEvents(ctx, w, path), w.Errors
+		}
+	}
+
+	// 停止状态的 timer，收到文件事件后重置，合并短时间内的多次写入
+	debounce := time.NewTimer(time.Hour)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			m.reloadAndLog("SIGHUP")
+		case <-events:
+			debounce.Reset(reloadDebounce)
+		case <-debounce.C:
+			m.reloadAndLog("config file change")
+		case err := <-errs:
+			slog.Warn("Config watch error", "error", err)
+		}
+	}
+}
+
+func (m *Manager) reloadAndLog(trigger string) {
+	slog.Info("Config reload triggered", "trigger", trigger)
+	if err := m.Reload(); err != nil {
+		slog.Error("Config reload rejected, keeping current config", "error", err)
+	}
+}
+
+// watchFile 监听配置文件所在目录而不是文件本身：编辑器保存与 Kubernetes ConfigMap
+// 更新都是替换文件（rename / symlink 切换），直接监听文件会在第一次替换后失效
+func watchFile(path string) (*fsnotify.Watcher, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := w.Add(filepath.Dir(path)); err != nil {
+		_ = w.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+// filterEvents 只转发与配置文件相关的事件
+func filterEvents(ctx context.Context, w *fsnotify.Watcher, path string) <-chan fsnotify.Event {
+	target := filepath.Clean(path)
+	out := make(chan fsnotify.Event)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev, ok := <-w.Events:
+				if !ok {
+					return
+				}
+				name := filepath.Clean(ev.Name)
+				// ..data 为 Kubernetes ConfigMap 挂载时切换的符号链接
+				if name != target && filepath.Base(name) != "..data" {
+					continue
+				}
+				select {
+				case out <- ev:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// diffConfig 按 YAML 路径递归比较 a、b 两份配置，对每个不同的叶子字段调用 fn。
+// target 为 dst 中对应的字段，供调用方按需覆盖。
+func diffConfig(prefix string, a, b, dst reflect.Value, fn func(path string, from, to, target reflect.Value)) {
+	t := a.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+
+		path := prefix
+		if opts != "inline" {
+			if name == "" {
+				name = strings.ToLower(field.Name)
+			}
+			path = joinPath(prefix, name)
+		}
+
+		av, bv, dv := a.Field(i), b.Field(i), dst.Field(i)
+		if field.Type.Kind() == reflect.Struct {
+			diffConfig(path, av, bv, dv, fn)
+			continue
+		}
+		if !reflect.DeepEqual(av.Interface(), bv.Interface()) {
+			fn(path, av, bv, dv)
+		}
+	}
+}
+
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+func formatValue(path string, v reflect.Value) string {
+	if sensitive[path] {
+		return "<redacted>"
+	}
+	if v.Kind() == reflect.String {
+		return fmt.Sprintf("%q", v.String())
+	}
+	return fmt.Sprint(v.Interface())
+}
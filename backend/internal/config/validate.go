@@ -0,0 +1,144 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Validate 校验配置，返回全部错误（errors.Join），而不是遇到第一个就停止
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	s := c.Server
+	if port, err := strconv.Atoi(s.Port); err != nil || port < 1 || port > 65535 {
+		v.addf("server.port", "PORT", "must be a port number between 1 and 65535, got %q", s.Port)
+	}
+	v.oneOf("server.mode", "GIN_MODE", s.Mode, "debug", "release", "test")
+	v.positive("server.read_timeout", "HTTP_READ_TIMEOUT", s.ReadTimeout)
+	v.positive("server.write_timeout", "HTTP_WRITE_TIMEOUT", s.WriteTimeout)
+	v.positive("server.idle_timeout", "HTTP_IDLE_TIMEOUT", s.IdleTimeout)
+	v.positive("server.shutdown_timeout", "HTTP_SHUTDOWN_TIMEOUT", s.ShutdownTimeout)
+	for _, p := range s.TrustedProxyList() {
+		if net.ParseIP(p) == nil {
+			if _, _, err := net.ParseCIDR(p); err != nil {
+				v.addf("server.trusted_proxies", "TRUSTED_PROXIES", "must be IP addresses or CIDRs, got %q", p)
+			}
+		}
+	}
+
+	v.oneOf("log.level", "LOG_LEVEL", c.Log.Level, "debug", "info", "warn", "error")
+	v.oneOf("log.format", "LOG_FORMAT", c.Log.Format, "json", "text")
+
+	if addr := c.Metrics.Addr; addr != "" {
+		if _, port, err := net.SplitHostPort(addr); err != nil {
+			v.addf("metrics.addr", "METRICS_ADDR", "must be host:port or :port, got %q", addr)
+		} else if port == s.Port {
+			v.addf("metrics.addr", "METRICS_ADDR", "must not use the public server port %s", s.Port)
+		}
+	}
+
+	db := c.Infra.DB
+	v.required("database.dsn", "DATABASE_URL", db.DSN)
+	v.atLeast("database.max_open_conns", "DB_MAX_OPEN_CONNS", db.MaxOpenConns, 1)
+	v.atLeast("database.max_idle_conns", "DB_MAX_IDLE_CONNS", db.MaxIdleConns, 0)
+	if db.MaxIdleConns > db.MaxOpenConns && db.MaxOpenConns > 0 {
+		v.addf("database.max_idle_conns", "DB_MAX_IDLE_CONNS", "must not exceed max_open_conns (%d)", db.MaxOpenConns)
+	}
+	v.positive("database.conn_max_lifetime", "DB_CONN_MAX_LIFETIME", db.ConnMaxLifetime)
+	v.positive("database.conn_max_idle_time", "DB_CONN_MAX_IDLE_TIME", db.ConnMaxIdleTime)
+	v.positive("database.connect_timeout", "DB_CONNECT_TIMEOUT", db.ConnectTimeout)
+
+	rds := c.Infra.Redis
+	v.required("redis.url", "REDIS_URL", rds.URL)
+	v.atLeast("redis.pool_size", "REDIS_POOL_SIZE", rds.PoolSize, 1)
+	v.atLeast("redis.min_idle_conns", "REDIS_MIN_IDLE_CONNS", rds.MinIdleConns, 0)
+	v.positive("redis.dial_timeout", "REDIS_DIAL_TIMEOUT", rds.DialTimeout)
+	v.positive("redis.read_timeout", "REDIS_READ_TIMEOUT", rds.ReadTimeout)
+	v.positive("redis.write_timeout", "REDIS_WRITE_TIMEOUT", rds.WriteTimeout)
+
+	t := c.Tracing
+	v.oneOf("tracing.exporter", "TRACING_EXPORTER", t.Exporter, "none", "otlp", "stdout")
+	if t.SampleRatio < 0 || t.SampleRatio > 1 {
+		v.addf("tracing.sample_ratio", "TRACING_SAMPLE_RATIO", "must be between 0 and 1, got %g", t.SampleRatio)
+	}
+	v.required("tracing.service_name", "TRACING_SERVICE_NAME", t.ServiceName)
+
+	a := c.Auth
+	v.oneOf("auth.registration_mode", "REGISTRATION_MODE", a.RegistrationMode, "open", "invite", "closed")
+	v.oneOf("auth.cookie_samesite", "COOKIE_SAMESITE", a.CookieSameSite, "lax", "strict", "none")
+	if a.CookieSameSite == "none" && !a.CookieSecure {
+		v.addf("auth.cookie_samesite", "COOKIE_SAMESITE", "none requires cookie_secure=true")
+	}
+	v.required("auth.jwt_issuer", "JWT_ISSUER", a.JWTIssuer)
+	v.atLeast("auth.jwt_access_ttl_minutes", "JWT_ACCESS_TTL_MINUTES", a.JWTAccessTTLMinutes, 1)
+	v.atLeast("auth.jwt_refresh_ttl_days", "JWT_REFRESH_TTL_DAYS", a.JWTRefreshTTLDays, 1)
+	if (a.AdminEmail != "" || a.AdminPassword != "") && a.AdminUsername == "" {
+		v.addf("auth.admin_username", "ADMIN_USERNAME", "is required when admin email or password is set")
+	}
+	v.atLeast("auth.login_max_failures_per_user", "LOGIN_MAX_FAILURES_PER_USER", a.LoginMaxFailuresPerUser, 1)
+	v.atLeast("auth.login_max_failures_per_ip", "LOGIN_MAX_FAILURES_PER_IP", a.LoginMaxFailuresPerIP, 1)
+	v.oneOf("auth.password_hash_algorithm", "PASSWORD_HASH_ALGORITHM", a.PasswordHashAlgorithm, "argon2id", "bcrypt")
+	v.between("auth.bcrypt_cost", "BCRYPT_COST", a.BcryptCost, 4, 31)
+	v.atLeast("auth.argon2_memory_kib", "ARGON2_MEMORY_KIB", a.Argon2Memory, 8*a.Argon2Parallelism)
+	v.atLeast("auth.argon2_iterations", "ARGON2_ITERATIONS", a.Argon2Iterations, 1)
+	v.between("auth.argon2_parallelism", "ARGON2_PARALLELISM", a.Argon2Parallelism, 1, 255)
+	v.atLeast("auth.password_min_length", "PASSWORD_MIN_LENGTH", a.PasswordMinLength, 1)
+	if a.PasswordMaxLength < a.PasswordMinLength {
+		v.addf("auth.password_max_length", "PASSWORD_MAX_LENGTH", "must not be less than password_min_length (%d)", a.PasswordMinLength)
+	}
+	v.between("auth.password_min_char_classes", "PASSWORD_MIN_CHAR_CLASSES", a.PasswordMinCharClasses, 0, 4)
+	v.oneOf("auth.breach_check_mode", "BREACH_CHECK_MODE", a.BreachCheckMode, "off", "offline", "api")
+	if a.BreachCheckMode == "api" {
+		v.required("auth.breach_api_url", "BREACH_API_URL", a.BreachAPIURL)
+	}
+	v.atLeast("auth.account_deletion_grace_days", "ACCOUNT_DELETION_GRACE_DAYS", a.AccountDeletionGraceDays, 1)
+
+	if c.IDGen.NodeID > 1023 {
+		v.addf("idgen.node_id", "IDGEN_NODE_ID", "must be at most 1023 (negative to lease from redis), got %d", c.IDGen.NodeID)
+	}
+
+	return errors.Join(v.errs...)
+}
+
+// validator 收集校验错误，错误信息同时给出配置文件路径与环境变量名
+type validator struct {
+	errs []error
+}
+
+func (v *validator) addf(field, env, format string, args ...any) {
+	v.errs = append(v.errs, fmt.Errorf("%s (%s): %s", field, env, fmt.Sprintf(format, args...)))
+}
+
+func (v *validator) required(field, env, value string) {
+	if value == "" {
+		v.addf(field, env, "is required")
+	}
+}
+
+func (v *validator) oneOf(field, env, value string, allowed ...string) {
+	if !slices.Contains(allowed, value) {
+		v.addf(field, env, "must be one of %v, got %q", allowed, value)
+	}
+}
+
+func (v *validator) positive(field, env string, d time.Duration) {
+	if d <= 0 {
+		v.addf(field, env, "must be a positive duration, got %s", d)
+	}
+}
+
+func (v *validator) atLeast(field, env string, n, lo int) {
+	if n < lo {
+		v.addf(field, env, "must be at least %d, got %d", lo, n)
+	}
+}
+
+func (v *validator) between(field, env string, n, lo, hi int) {
+	if n < lo || n > hi {
+		v.addf(field, env, "must be between %d and %d, got %d", lo, hi, n)
+	}
+}
@@ -0,0 +1,213 @@
+package idgen
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"mygo/internal/infra"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const leaseKeyPrefix = "idgen:node:"
+
+// ErrLeaseLost 节点 ID 租约已丢失或已过期未续期，继续生成可能与其他实例冲突。
+// 租约丢失后后台会重新租用节点 ID，成功后恢复
+var ErrLeaseLost = errors.New("idgen: node id lease lost")
+
+// renewScript 仅当 key 仍属于自己时续期
+var renewScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// releaseScript 仅当 key 仍属于自己时删除
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// NodeLease 通过 Redis 租用的节点 ID，后台定期续期；租约丢失后重新租用，节点 ID 可能随之改变
+type NodeLease struct {
+	rdb   *infra.RedisClient
+	owner string
+	ttl   time.Duration
+
+	mu     sync.Mutex
+	nodeID int64
+	// expiresAt 租约在 Redis 中最晚的过期时间（以发出 SET/续期请求的时刻计），
+	// 续期失败期间超过该时间后 key 可能已被其他实例占用
+	expiresAt time.Time
+	err       error
+	// onChange 重新租用到节点 ID 后调用，用于切换生成器的节点 ID
+	onChange func(nodeID int64)
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// LeaseNodeID 从 Redis 租用一个空闲节点 ID，并按 ttl/3 间隔续期
+func LeaseNodeID(ctx context.Context, rdb *infra.RedisClient, ttl time.Duration) (*NodeLease, error) {
+	if rdb == nil {
+		return nil, errors.New("idgen: redis is nil")
+	}
+	if ttl <= 0 {
+		ttl = 30 * time.Second
+	}
+
+	ownerBytes := make([]byte, 16)
+	if _, err := rand.Read(ownerBytes); err != nil {
+		return nil, fmt.Errorf("idgen: generate lease owner: %w", err)
+	}
+	owner := hex.EncodeToString(ownerBytes)
+
+	nodeID, expiresAt, err := acquire(ctx, rdb, owner, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	renewCtx, cancel := context.WithCancel(context.Background())
+	l := &NodeLease{
+		rdb:       rdb,
+		nodeID:    nodeID,
+		owner:     owner,
+		ttl:       ttl,
+		expiresAt: expiresAt,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+	go l.renewLoop(renewCtx)
+	return l, nil
+}
+
+// acquire 按编号顺序抢占第一个空闲节点 ID
+func acquire(ctx context.Context, rdb *infra.RedisClient, owner string, ttl time.Duration) (int64, time.Time, error) {
+	for nodeID := int64(0); nodeID <= MaxNodeID; nodeID++ {
+		sent := time.Now()
+		ok, err := rdb.SetNX(ctx, leaseKey(nodeID), owner, ttl).Result()
+		if err != nil {
+			return 0, time.Time{}, fmt.Errorf("idgen: lease node id: %w", err)
+		}
+		if ok {
+			return nodeID, sent.Add(ttl), nil
+		}
+	}
+	return 0, time.Time{}, errors.New("idgen: no free node id")
+}
+
+// NodeID 返回当前租用的节点 ID
+func (l *NodeLease) NodeID() int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.nodeID
+}
+
+// OnChange 设置重新租用到节点 ID 后的回调，通常为 Snowflake.SetNodeID。
+// 回调在恢复生成之前执行，保证之后生成的 ID 使用新的节点 ID
+func (l *NodeLease) OnChange(fn func(nodeID int64)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.onChange = fn
+}
+
+// Err 租约丢失或超过过期时间仍未续期成功时返回 ErrLeaseLost，可作为 Snowflake 的 guard
+func (l *NodeLease) Err() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err == nil && !time.Now().Before(l.expiresAt) {
+		return ErrLeaseLost
+	}
+	return l.err
+}
+
+// Close 停止续期并释放租约
+func (l *NodeLease) Close() error {
+	l.cancel()
+	<-l.done
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	return releaseScript.Run(ctx, l.rdb, []string{leaseKey(l.NodeID())}, l.owner).Err()
+}
+
+func (l *NodeLease) renewLoop(ctx context.Context) {
+	defer close(l.done)
+
+	ticker := time.NewTicker(l.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		l.mu.Lock()
+		lost := l.err != nil
+		l.mu.Unlock()
+
+		if lost {
+			l.reacquire(ctx)
+		} else {
+			l.renew(ctx)
+		}
+	}
+}
+
+// renew 续期当前租约，key 已不属于自己或过期后仍续期失败时标记为丢失
+func (l *NodeLease) renew(ctx context.Context) {
+	nodeID := l.NodeID()
+	sent := time.Now()
+	renewCtx, cancel := context.WithTimeout(ctx, l.ttl/3)
+	n, err := renewScript.Run(renewCtx, l.rdb, []string{leaseKey(nodeID)}, l.owner, l.ttl.Milliseconds()).Int64()
+	cancel()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	switch {
+	case err != nil && time.Now().Before(l.expiresAt):
+		// 暂时性错误：租约未过期前仍可重试，过期后 Err 即返回 ErrLeaseLost
+		slog.Warn("idgen: renew node id lease failed", "node_id", nodeID, "error", err)
+	case err != nil || n == 0:
+		slog.Error("idgen: node id lease lost, re-leasing", "node_id", nodeID)
+		l.err = ErrLeaseLost
+	default:
+		l.expiresAt = sent.Add(l.ttl)
+	}
+}
+
+// reacquire 租约丢失后重新租用节点 ID，先切换生成器的节点 ID 再恢复生成
+func (l *NodeLease) reacquire(ctx context.Context) {
+	acquireCtx, cancel := context.WithTimeout(ctx, l.ttl/3)
+	nodeID, expiresAt, err := acquire(acquireCtx, l.rdb, l.owner, l.ttl)
+	cancel()
+	if err != nil {
+		slog.Warn("idgen: re-lease node id failed", "error", err)
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.onChange != nil {
+		l.onChange(nodeID)
+	}
+	l.nodeID = nodeID
+	l.expiresAt = expiresAt
+	l.err = nil
+	slog.Info("idgen: node id re-leased", "node_id", nodeID)
+}
+
+func leaseKey(nodeID int64) string {
+	return leaseKeyPrefix + strconv.FormatInt(nodeID, 10)
+}
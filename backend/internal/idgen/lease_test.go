@@ -0,0 +1,70 @@
+package idgen
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestNodeLeaseReacquiresAfterLoss(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	lease, err := LeaseNodeID(context.Background(), rdb, 300*time.Millisecond)
+	if err != nil {
+		t.Fatalf("LeaseNodeID: %v", err)
+	}
+	t.Cleanup(func() { _ = lease.Close() })
+	if lease.NodeID() != 0 {
+		t.Fatalf("NodeID = %d, want 0", lease.NodeID())
+	}
+
+	gen, err := NewSnowflake(lease.NodeID(), WithGuard(lease.Err))
+	if err != nil {
+		t.Fatalf("NewSnowflake: %v", err)
+	}
+	lease.OnChange(gen.SetNodeID)
+
+	// 其他实例接管了节点 0：续期失败，随后重新租用到节点 1
+	if err := mr.Set(leaseKey(0), "other"); err != nil {
+		t.Fatalf("set lease key: %v", err)
+	}
+
+	var sawLost bool
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		err := lease.Err()
+		if errors.Is(err, ErrLeaseLost) {
+			sawLost = true
+			if _, genErr := gen.NextID(); !errors.Is(genErr, ErrLeaseLost) {
+				t.Fatalf("NextID while lost: err = %v, want ErrLeaseLost", genErr)
+			}
+		}
+		if sawLost && err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("lease not re-acquired: sawLost=%v err=%v", sawLost, err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if lease.NodeID() != 1 || gen.NodeID() != 1 {
+		t.Fatalf("NodeID lease=%d gen=%d, want 1", lease.NodeID(), gen.NodeID())
+	}
+	id, err := gen.NextID()
+	if err != nil {
+		t.Fatalf("NextID: %v", err)
+	}
+	if _, nodeID, _ := Parse(id); nodeID != 1 {
+		t.Fatalf("generated node id = %d, want 1", nodeID)
+	}
+	if got, _ := mr.Get(leaseKey(1)); got == "" {
+		t.Fatal("lease key for node 1 not set")
+	}
+}
@@ -0,0 +1,153 @@
+package idgen
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Snowflake 位布局：1 位符号 | 41 位毫秒时间戳 | 10 位节点 ID | 12 位序列号
+const (
+	nodeBits     = 10
+	sequenceBits = 12
+
+	MaxNodeID   = 1<<nodeBits - 1
+	maxSequence = 1<<sequenceBits - 1
+
+	timeShift = nodeBits + sequenceBits
+	nodeShift = sequenceBits
+)
+
+// Epoch 自定义纪元（2024-01-01 UTC），41 位时间戳可用约 69 年
+var Epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// ErrClockMovedBackwards 时钟回拨超过可容忍范围
+var ErrClockMovedBackwards = errors.New("idgen: clock moved backwards")
+
+// Generator ID 生成器
+type Generator interface {
+	NextID() (int64, error)
+}
+
+// Snowflake 并发安全的雪花 ID 生成器
+type Snowflake struct {
+	mu       sync.Mutex
+	nodeID   int64
+	lastMS   int64
+	sequence int64
+
+	// maxBackward 可等待的最大时钟回拨，超过则返回错误
+	maxBackward time.Duration
+	// guard 每次生成前调用，返回错误时拒绝生成（如节点租约丢失）
+	guard func() error
+	now   func() time.Time
+}
+
+// Option Snowflake 可选配置
+type Option func(*Snowflake)
+
+// WithMaxBackward 设置可容忍的时钟回拨，默认 10ms
+func WithMaxBackward(d time.Duration) Option {
+	return func(s *Snowflake) { s.maxBackward = d }
+}
+
+// WithGuard 设置生成前检查
+func WithGuard(guard func() error) Option {
+	return func(s *Snowflake) { s.guard = guard }
+}
+
+// NewSnowflake 创建生成器，nodeID 取值 [0, MaxNodeID]，同一时刻各实例必须唯一
+func NewSnowflake(nodeID int64, opts ...Option) (*Snowflake, error) {
+	if nodeID < 0 || nodeID > MaxNodeID {
+		return nil, fmt.Errorf("idgen: node id %d out of range [0, %d]", nodeID, MaxNodeID)
+	}
+	s := &Snowflake{
+		nodeID:      nodeID,
+		maxBackward: 10 * time.Millisecond,
+		now:         time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
+}
+
+// NodeID 返回节点 ID
+func (s *Snowflake) NodeID() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.nodeID
+}
+
+// SetNodeID 切换节点 ID（如重新租用节点 ID 后），超出范围时忽略
+func (s *Snowflake) SetNodeID(nodeID int64) {
+	if nodeID < 0 || nodeID > MaxNodeID {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nodeID = nodeID
+}
+
+// NextID 生成下一个 ID
+func (s *Snowflake) NextID() (int64, error) {
+	if s.guard != nil {
+		if err := s.guard(); err != nil {
+			return 0, err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ms := s.currentMS()
+	if ms < s.lastMS {
+		// 小幅回拨等待追平，大幅回拨直接报错，避免生成重复 ID
+		backward := time.Duration(s.lastMS-ms) * time.Millisecond
+		if backward > s.maxBackward {
+			return 0, fmt.Errorf("%w by %s", ErrClockMovedBackwards, backward)
+		}
+		time.Sleep(backward)
+		ms = s.waitAfter(s.lastMS - 1)
+	}
+
+	if ms == s.lastMS {
+		s.sequence = (s.sequence + 1) & maxSequence
+		if s.sequence == 0 {
+			// 当前毫秒序列号用尽，等待下一毫秒
+			ms = s.waitAfter(s.lastMS)
+		}
+	} else {
+		s.sequence = 0
+	}
+	s.lastMS = ms
+
+	return ms<<timeShift | s.nodeID<<nodeShift | s.sequence, nil
+}
+
+// currentMS 当前时间相对纪元的毫秒数
+func (s *Snowflake) currentMS() int64 {
+	return s.now().Sub(Epoch).Milliseconds()
+}
+
+// waitAfter 自旋等待直到时间戳大于 ms
+func (s *Snowflake) waitAfter(ms int64) int64 {
+	now := s.currentMS()
+	for now <= ms {
+		time.Sleep(100 * time.Microsecond)
+		now = s.currentMS()
+	}
+	return now
+}
+
+// Parse 拆解 ID 的生成时间、节点 ID 与序列号
+func Parse(id int64) (at time.Time, nodeID, sequence int64) {
+	ms := id >> timeShift
+	nodeID = (id >> nodeShift) & MaxNodeID
+	sequence = id & maxSequence
+	return Epoch.Add(time.Duration(ms) * time.Millisecond), nodeID, sequence
+}
+
+// 确保 Snowflake 实现了 Generator 接口
+var _ Generator = (*Snowflake)(nil)
@@ -3,25 +3,24 @@ package infra
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // NewPG 连接 PostgreSQL
-func NewPG(dsn string) (*pgxpool.Pool, error) {
-	config, err := pgxpool.ParseConfig(dsn)
+func NewPG(cfg DBConfig) (*pgxpool.Pool, error) {
+	config, err := pgxpool.ParseConfig(cfg.DSN)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse database config: %w", err)
 	}
 
 	// 连接池配置
-	config.MaxConns = 10                       // 最大连接数
-	config.MinConns = 2                        // 最小空闲连接
-	config.MaxConnLifetime = 1 * time.Hour     // 连接最大存活时间
-	config.MaxConnIdleTime = 30 * time.Minute  // 空闲连接最大存活时间
+	config.MaxConns = int32(cfg.MaxOpenConns)    // 最大连接数
+	config.MinConns = int32(cfg.MaxIdleConns)    // 最小空闲连接
+	config.MaxConnLifetime = cfg.ConnMaxLifetime // 连接最大存活时间
+	config.MaxConnIdleTime = cfg.ConnMaxIdleTime // 空闲连接最大存活时间
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ConnectTimeout)
 	defer cancel()
 
 	pool, err := pgxpool.NewWithConfig(ctx, config)
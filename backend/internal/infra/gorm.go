@@ -1,11 +1,16 @@
 package infra
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"time"
 
+	"mygo/internal/logging"
+
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 	"gorm.io/gorm/schema"
 )
 
@@ -16,16 +21,25 @@ type GormDB = gorm.DB
 // ErrRecordNotFound 透出给上层做 errors.Is 判断。
 var ErrRecordNotFound = gorm.ErrRecordNotFound
 
-func NewGormPG(dsn string) (*gorm.DB, error) {
-	if dsn == "" {
+// NewGormPG 连接 PostgreSQL 并按 cfg 配置连接池
+func NewGormPG(cfg DBConfig) (*gorm.DB, error) {
+	if cfg.DSN == "" {
 		return nil, fmt.Errorf("failed to open gorm: dsn is empty")
 	}
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+	db, err := gorm.Open(postgres.Open(cfg.DSN), &gorm.Config{
 		// DryRun: true, // 开启时打印 SQL 但不执行
 		NamingStrategy: schema.NamingStrategy{
 			SingularTable: true,
 		},
+		// 改为下方带超时的 Ping
+		DisableAutomaticPing: true,
+		// SQL 错误与慢查询输出到 slog，并带上 ctx 中的 request_id
+		Logger: logger.NewSlogLogger(slog.New(logging.NewContextHandler(slog.Default().Handler())), logger.Config{
+			SlowThreshold:             200 * time.Millisecond,
+			LogLevel:                  logger.Warn,
+			IgnoreRecordNotFoundError: true,
+		}),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to open gorm: %w", err)
@@ -36,10 +50,18 @@ func NewGormPG(dsn string) (*gorm.DB, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to get gorm sql db: %w", err)
 	}
-	sqlDB.SetMaxOpenConns(10)
-	sqlDB.SetMaxIdleConns(2)
-	sqlDB.SetConnMaxLifetime(1 * time.Hour)
-	sqlDB.SetConnMaxIdleTime(30 * time.Minute)
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	// 启动时 Ping 一次尽早暴露配置错误，避免数据库不可达时无限等待
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ConnectTimeout)
+	defer cancel()
+	if err := sqlDB.PingContext(ctx); err != nil {
+		_ = sqlDB.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
 
 	return db, nil
 }
@@ -0,0 +1,101 @@
+package infra
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// gormTracerName 创建 span 使用的 instrumentation 名称
+const gormTracerName = "mygo/internal/infra/gorm"
+
+// gormSpanKey 在 gorm.Statement 中暂存 span 的 key
+const gormSpanKey = "otel:span"
+
+// TracingPlugin 为每条 SQL 创建一个 span（名称为 gorm.create / gorm.query 等），
+// 记录 SQL 语句（参数以占位符表示）、表名、影响行数与错误。
+// span 挂在 db.WithContext(ctx) 传入的 ctx 上，因此仓储必须传递请求的 ctx。
+type TracingPlugin struct{}
+
+// NewTracingPlugin 构造函数
+func NewTracingPlugin() *TracingPlugin {
+	return &TracingPlugin{}
+}
+
+// Name 实现 gorm.Plugin
+func (p *TracingPlugin) Name() string {
+	return "otel-tracing"
+}
+
+// Initialize 实现 gorm.Plugin，在各类回调的首尾注册钩子
+func (p *TracingPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	hooks := []struct {
+		op     string
+		before func(string, func(*gorm.DB)) error
+		after  func(string, func(*gorm.DB)) error
+	}{
+		{"create", cb.Create().Before("gorm:create").Register, cb.Create().After("gorm:create").Register},
+		{"query", cb.Query().Before("gorm:query").Register, cb.Query().After("gorm:query").Register},
+		{"update", cb.Update().Before("gorm:update").Register, cb.Update().After("gorm:update").Register},
+		{"delete", cb.Delete().Before("gorm:delete").Register, cb.Delete().After("gorm:delete").Register},
+		{"row", cb.Row().Before("gorm:row").Register, cb.Row().After("gorm:row").Register},
+		{"raw", cb.Raw().Before("gorm:raw").Register, cb.Raw().After("gorm:raw").Register},
+	}
+
+	var errs []error
+	for _, h := range hooks {
+		errs = append(errs,
+			h.before("otel:before_"+h.op, startSpan("gorm."+h.op)),
+			h.after("otel:after_"+h.op, endSpan),
+		)
+	}
+	return errors.Join(errs...)
+}
+
+func startSpan(name string) func(*gorm.DB) {
+	tracer := otel.Tracer(gormTracerName)
+	return func(db *gorm.DB) {
+		if db.Statement == nil || db.Statement.Context == nil {
+			return
+		}
+		ctx, span := tracer.Start(db.Statement.Context, name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemNamePostgreSQL),
+		)
+		db.Statement.Context = ctx
+		db.InstanceSet(gormSpanKey, span)
+	}
+}
+
+func endSpan(db *gorm.DB) {
+	v, ok := db.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+	span, ok := v.(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	if !span.IsRecording() {
+		return
+	}
+	span.SetAttributes(
+		semconv.DBQueryText(db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+	)
+	if table := db.Statement.Table; table != "" {
+		span.SetAttributes(semconv.DBCollectionName(table))
+	}
+	if err := db.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
@@ -0,0 +1,167 @@
+package infra
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"mygo/internal/tracing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type tracingTestUser struct {
+	ID   int64
+	Name string
+}
+
+// newTracedTestDB 用 tracing.NewProvider 与内存 exporter 搭建全局 TracerProvider，
+// 并打开注册了 TracingPlugin 的 GORM。DSN 指向不可达的地址：DryRun 会话只生成 SQL，
+// 真实执行则以连接错误结束。
+func newTracedTestDB(t *testing.T) (*gorm.DB, *tracetest.InMemoryExporter) {
+	t.Helper()
+
+	exp := tracetest.NewInMemoryExporter()
+	tp, err := tracing.NewProvider(context.Background(), tracing.DefaultConfig(), sdktrace.WithSyncer(exp))
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prev)
+		_ = tp.Shutdown(context.Background())
+	})
+
+	db, err := gorm.Open(postgres.Open("host=127.0.0.1 port=1 user=test dbname=test sslmode=disable connect_timeout=1"), &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open: %v", err)
+	}
+	if err := db.Use(NewTracingPlugin()); err != nil {
+		t.Fatalf("Use(TracingPlugin): %v", err)
+	}
+	return db, exp
+}
+
+func spanAttr(span tracetest.SpanStub, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestTracingPluginSpans(t *testing.T) {
+	db, exp := newTracedTestDB(t)
+	// 跳过默认事务，否则写操作在 BEGIN 时就会连接数据库
+	dry := db.Session(&gorm.Session{DryRun: true, SkipDefaultTransaction: true})
+
+	tests := []struct {
+		name     string
+		run      func(ctx context.Context)
+		wantSpan string
+		wantSQL  string
+	}{
+		{
+			name:     "create",
+			run:      func(ctx context.Context) { dry.WithContext(ctx).Create(&tracingTestUser{Name: "anon"}) },
+			wantSpan: "gorm.create",
+			wantSQL:  "INSERT INTO",
+		},
+		{
+			name: "query",
+			run: func(ctx context.Context) {
+				var u tracingTestUser
+				dry.WithContext(ctx).Where("name = ?", "anon").First(&u)
+			},
+			wantSpan: "gorm.query",
+			wantSQL:  "SELECT * FROM",
+		},
+		{
+			name:     "update",
+			run:      func(ctx context.Context) { dry.WithContext(ctx).Model(&tracingTestUser{ID: 1}).Update("name", "soyo") },
+			wantSpan: "gorm.update",
+			wantSQL:  "UPDATE",
+		},
+		{
+			name:     "delete",
+			run:      func(ctx context.Context) { dry.WithContext(ctx).Delete(&tracingTestUser{ID: 1}) },
+			wantSpan: "gorm.delete",
+			wantSQL:  "DELETE FROM",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exp.Reset()
+
+			ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+			tt.run(ctx)
+			parent.End()
+
+			spans := exp.GetSpans()
+			if len(spans) != 2 {
+				t.Fatalf("got %d spans, want 2 (gorm + parent)", len(spans))
+			}
+			span := spans[0]
+			if span.Name != tt.wantSpan {
+				t.Fatalf("span name = %q, want %q", span.Name, tt.wantSpan)
+			}
+			if span.SpanKind != trace.SpanKindClient {
+				t.Errorf("span kind = %v, want client", span.SpanKind)
+			}
+			if span.Parent.SpanID() != parent.SpanContext().SpanID() {
+				t.Errorf("gorm span is not a child of the ctx span")
+			}
+			if v, ok := spanAttr(span, "db.system.name"); !ok || v.AsString() != "postgresql" {
+				t.Errorf("db.system.name = %v, want postgresql", v.Emit())
+			}
+			if v, ok := spanAttr(span, "db.query.text"); !ok || !strings.Contains(v.AsString(), tt.wantSQL) {
+				t.Errorf("db.query.text = %q, want it to contain %q", v.AsString(), tt.wantSQL)
+			}
+			if v, ok := spanAttr(span, "db.collection.name"); !ok || v.AsString() != "tracing_test_users" {
+				t.Errorf("db.collection.name = %q, want tracing_test_users", v.AsString())
+			}
+			if span.Status.Code == codes.Error {
+				t.Errorf("span status = error, want unset")
+			}
+		})
+	}
+}
+
+func TestTracingPluginRecordsErrors(t *testing.T) {
+	db, exp := newTracedTestDB(t)
+
+	var u tracingTestUser
+	err := db.WithContext(context.Background()).First(&u).Error
+	if err == nil {
+		t.Fatal("query against an unreachable database succeeded")
+	}
+
+	spans := exp.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "gorm.query" {
+		t.Fatalf("span name = %q, want gorm.query", span.Name)
+	}
+	if span.Status.Code != codes.Error {
+		t.Errorf("span status = %v, want error", span.Status.Code)
+	}
+	if len(span.Events) == 0 || span.Events[0].Name != "exception" {
+		t.Errorf("span events = %v, want a recorded exception", span.Events)
+	}
+}
@@ -0,0 +1,160 @@
+package infra
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 健康检查默认参数
+const (
+	defaultHealthTimeout  = 2 * time.Second
+	defaultHealthCacheTTL = 2 * time.Second
+)
+
+// HealthStatus 整体健康状态
+type HealthStatus string
+
+const (
+	// HealthOK 全部检查通过
+	HealthOK HealthStatus = "ok"
+	// HealthDegraded 仅非关键检查失败，仍可接收流量
+	HealthDegraded HealthStatus = "degraded"
+	// HealthUnavailable 关键检查失败或正在关闭，不应接收流量
+	HealthUnavailable HealthStatus = "unavailable"
+)
+
+// HealthCheck 一项依赖检查
+type HealthCheck struct {
+	Name string
+	// Check 返回 nil 表示健康，ctx 带有 Timeout
+	Check func(ctx context.Context) error
+	// Critical 关键检查失败时 readiness 失败；非关键检查失败只把状态标记为 degraded
+	Critical bool
+	// Timeout 单次检查超时，默认 2s
+	Timeout time.Duration
+	// CacheTTL 结果缓存时间，默认 2s，避免探针频繁请求压到依赖上
+	CacheTTL time.Duration
+}
+
+// HealthCheckResult 单项检查结果
+type HealthCheckResult struct {
+	Name       string    `json:"name"`
+	Healthy    bool      `json:"healthy"`
+	Critical   bool      `json:"critical"`
+	Error      string    `json:"error,omitempty"`
+	DurationMS float64   `json:"duration_ms"`
+	CheckedAt  time.Time `json:"checked_at"`
+}
+
+// HealthReport 全部检查的汇总
+type HealthReport struct {
+	Status   HealthStatus        `json:"status"`
+	Draining bool                `json:"draining,omitempty"`
+	Checks   []HealthCheckResult `json:"checks"`
+}
+
+// Ready 是否可以接收流量
+func (r *HealthReport) Ready() bool {
+	return r.Status != HealthUnavailable
+}
+
+// HealthRegistry 健康检查注册表，各组件在初始化时注册自己的检查
+type HealthRegistry struct {
+	mu       sync.RWMutex
+	entries  []*healthEntry
+	draining atomic.Bool
+}
+
+type healthEntry struct {
+	check HealthCheck
+
+	mu   sync.Mutex // 同一检查同时只执行一次，并发的探针等待并共享结果
+	last HealthCheckResult
+}
+
+// NewHealthRegistry 构造函数
+func NewHealthRegistry() *HealthRegistry {
+	return &HealthRegistry{}
+}
+
+// Register 注册检查，Timeout / CacheTTL 为 0 时使用默认值
+func (r *HealthRegistry) Register(check HealthCheck) {
+	if check.Timeout <= 0 {
+		check.Timeout = defaultHealthTimeout
+	}
+	if check.CacheTTL <= 0 {
+		check.CacheTTL = defaultHealthCacheTTL
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, &healthEntry{check: check})
+}
+
+// SetDraining 标记进入优雅关闭，之后 readiness 一律失败，让负载均衡先摘除流量
+func (r *HealthRegistry) SetDraining() {
+	r.draining.Store(true)
+}
+
+// Check 并发执行全部检查（命中缓存的直接返回上次结果）并汇总
+func (r *HealthRegistry) Check(ctx context.Context) *HealthReport {
+	r.mu.RLock()
+	entries := r.entries
+	r.mu.RUnlock()
+
+	results := make([]HealthCheckResult, len(entries))
+	var wg sync.WaitGroup
+	for i, e := range entries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = e.run(ctx)
+		}()
+	}
+	wg.Wait()
+
+	report := &HealthReport{Status: HealthOK, Draining: r.draining.Load(), Checks: results}
+	for _, res := range results {
+		switch {
+		case res.Healthy:
+		case res.Critical:
+			report.Status = HealthUnavailable
+		case report.Status == HealthOK:
+			report.Status = HealthDegraded
+		}
+	}
+	if report.Draining {
+		report.Status = HealthUnavailable
+	}
+	return report
+}
+
+func (e *healthEntry) run(ctx context.Context) HealthCheckResult {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.last.CheckedAt.IsZero() && time.Since(e.last.CheckedAt) < e.check.CacheTTL {
+		return e.last
+	}
+
+	// 结果会被其他探针共享，不受本次调用方取消的影响
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), e.check.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := e.check.Check(ctx)
+	res := HealthCheckResult{
+		Name:       e.check.Name,
+		Healthy:    err == nil,
+		Critical:   e.check.Critical,
+		DurationMS: float64(time.Since(start).Microseconds()) / 1000,
+		CheckedAt:  start,
+	}
+	if err != nil {
+		res.Error = err.Error()
+	}
+	e.last = res
+	return res
+}
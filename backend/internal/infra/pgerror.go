@@ -0,0 +1,57 @@
+package infra
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Postgres 错误码，见 https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgUniqueViolation      = "23505"
+	pgForeignKeyViolation  = "23503"
+	pgSerializationFailure = "40001"
+)
+
+// 翻译后的数据库错误，上层通过 errors.Is 判断，不直接依赖驱动类型
+var (
+	ErrUniqueViolation      = errors.New("unique constraint violation")
+	ErrForeignKeyViolation  = errors.New("foreign key violation")
+	ErrSerializationFailure = errors.New("serialization failure")
+)
+
+// ConstraintError 约束冲突。errors.Is 可同时匹配 Kind（如 ErrUniqueViolation）与原始错误。
+type ConstraintError struct {
+	Kind       error
+	Table      string
+	Constraint string // 约束或唯一索引名，如 idx_users_email_canonical
+	Err        error
+}
+
+func (e *ConstraintError) Error() string {
+	return fmt.Sprintf("%s: table %s, constraint %s", e.Kind, e.Table, e.Constraint)
+}
+
+func (e *ConstraintError) Unwrap() []error { return []error{e.Kind, e.Err} }
+
+// TranslatePGError 把 Postgres 驱动错误转换为 infra 错误：
+// 唯一约束与外键冲突返回 *ConstraintError，序列化失败包装为 ErrSerializationFailure（可重试）。
+// 其他错误原样返回，nil 返回 nil。
+func TranslatePGError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch pgErr.Code {
+	case pgUniqueViolation:
+		return &ConstraintError{Kind: ErrUniqueViolation, Table: pgErr.TableName, Constraint: pgErr.ConstraintName, Err: err}
+	case pgForeignKeyViolation:
+		return &ConstraintError{Kind: ErrForeignKeyViolation, Table: pgErr.TableName, Constraint: pgErr.ConstraintName, Err: err}
+	case pgSerializationFailure:
+		return fmt.Errorf("%w: %w", ErrSerializationFailure, err)
+	default:
+		return err
+	}
+}
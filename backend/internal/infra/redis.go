@@ -3,7 +3,6 @@ package infra
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/redis/go-redis/v9"
 )
@@ -11,24 +10,26 @@ import (
 // RedisClient 作为 infra 暴露给上层的依赖类型别名
 type RedisClient = redis.Client
 
-// NewRedis 连接 Redis
-// url 示例: redis://:password@localhost:6379/0
-func NewRedis(url string) (*redis.Client, error) {
-	opts, err := redis.ParseURL(url)
+// ErrRedisNil 表示 key 不存在，透出给上层做 errors.Is 判断。
+var ErrRedisNil = redis.Nil
+
+// NewRedis 连接 Redis 并按 cfg 配置连接池
+func NewRedis(cfg RedisConfig) (*redis.Client, error) {
+	opts, err := redis.ParseURL(cfg.URL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse redis url: %w", err)
 	}
 
 	// 覆盖默认配置以优化生产环境
-	opts.DialTimeout = 5 * time.Second
-	opts.ReadTimeout = 3 * time.Second
-	opts.WriteTimeout = 3 * time.Second
-	opts.PoolSize = 10
-	opts.MinIdleConns = 2
+	opts.DialTimeout = cfg.DialTimeout
+	opts.ReadTimeout = cfg.ReadTimeout
+	opts.WriteTimeout = cfg.WriteTimeout
+	opts.PoolSize = cfg.PoolSize
+	opts.MinIdleConns = cfg.MinIdleConns
 
 	rdb := redis.NewClient(opts)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.DialTimeout)
 	defer cancel()
 
 	if err := rdb.Ping(ctx).Err(); err != nil {
@@ -1,45 +1,139 @@
 package infra
 
 import (
+	"context"
 	"fmt"
+	"time"
 )
 
 // Config 统一承载 infra 初始化需要的配置。
 type Config struct {
-	PGDSN    string
-	RedisURL string
+	DB      DBConfig      `yaml:"database"`
+	Redis   RedisConfig   `yaml:"redis"`
+	Storage StorageConfig `yaml:"storage"`
+}
+
+// DBConfig PostgreSQL 连接与连接池配置
+type DBConfig struct {
+	DSN string `yaml:"dsn"`
+
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"`
+	// ConnectTimeout 启动时建立连接并 Ping 的超时
+	ConnectTimeout time.Duration `yaml:"connect_timeout"`
+}
+
+// DefaultDBConfig 返回默认连接池配置，DSN 需另行设置
+func DefaultDBConfig() DBConfig {
+	return DBConfig{
+		MaxOpenConns:    10,
+		MaxIdleConns:    2,
+		ConnMaxLifetime: time.Hour,
+		ConnMaxIdleTime: 30 * time.Minute,
+		ConnectTimeout:  5 * time.Second,
+	}
+}
+
+// RedisConfig Redis 连接与连接池配置
+type RedisConfig struct {
+	// URL 示例: redis://:password@localhost:6379/0
+	URL string `yaml:"url"`
+
+	PoolSize     int           `yaml:"pool_size"`
+	MinIdleConns int           `yaml:"min_idle_conns"`
+	DialTimeout  time.Duration `yaml:"dial_timeout"`
+	ReadTimeout  time.Duration `yaml:"read_timeout"`
+	WriteTimeout time.Duration `yaml:"write_timeout"`
+}
+
+// DefaultRedisConfig 返回默认连接池配置，URL 需另行设置
+func DefaultRedisConfig() RedisConfig {
+	return RedisConfig{
+		PoolSize:     10,
+		MinIdleConns: 2,
+		DialTimeout:  5 * time.Second,
+		ReadTimeout:  3 * time.Second,
+		WriteTimeout: 3 * time.Second,
+	}
+}
+
+// StorageConfig 本地文件存储目录及对外访问前缀，Dir 为空时不启用
+type StorageConfig struct {
+	Dir     string `yaml:"dir"`
+	BaseURL string `yaml:"base_url"`
 }
 
 // Resources 表示应用运行所需的基础设施依赖集合。
 // 由 infra 负责创建和释放。
 type Resources struct {
-	Redis *RedisClient
-	DB    *GormDB
+	Redis   *RedisClient
+	DB      *GormDB
+	Storage *LocalStorage
+
+	// Health 依赖健康检查，已注册 postgres 与 redis，其他组件可追加
+	Health *HealthRegistry
 }
 
 func NewResources(cfg Config) (*Resources, error) {
-	if cfg.PGDSN == "" {
-		return nil, fmt.Errorf("infra: PGDSN is empty")
+	if cfg.DB.DSN == "" {
+		return nil, fmt.Errorf("infra: database dsn is empty")
 	}
-	if cfg.RedisURL == "" {
-		return nil, fmt.Errorf("infra: RedisURL is empty")
+	if cfg.Redis.URL == "" {
+		return nil, fmt.Errorf("infra: redis url is empty")
 	}
 
-	rdb, err := NewRedis(cfg.RedisURL)
+	rdb, err := NewRedis(cfg.Redis)
 	if err != nil {
 		return nil, err
 	}
 
-	gdb, err := NewGormPG(cfg.PGDSN)
+	gdb, err := NewGormPG(cfg.DB)
 	if err != nil {
 		_ = rdb.Close()
 		return nil, err
 	}
 
-	return &Resources{
-		Redis: rdb,
-		DB:    gdb,
-	}, nil
+	res := &Resources{
+		Redis:  rdb,
+		DB:     gdb,
+		Health: NewHealthRegistry(),
+	}
+	res.registerHealthChecks()
+
+	if cfg.Storage.Dir != "" {
+		storage, err := NewLocalStorage(cfg.Storage.Dir, cfg.Storage.BaseURL)
+		if err != nil {
+			_ = res.Close()
+			return nil, err
+		}
+		res.Storage = storage
+	}
+
+	return res, nil
+}
+
+// registerHealthChecks 注册数据库与 Redis 的连通性检查
+func (r *Resources) registerHealthChecks() {
+	r.Health.Register(HealthCheck{
+		Name:     "postgres",
+		Critical: true,
+		Check: func(ctx context.Context) error {
+			sqlDB, err := r.DB.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		},
+	})
+	r.Health.Register(HealthCheck{
+		Name:     "redis",
+		Critical: true,
+		Check: func(ctx context.Context) error {
+			return r.Redis.Ping(ctx).Err()
+		},
+	})
 }
 
 func (r *Resources) Close() error {
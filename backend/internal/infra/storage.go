@@ -0,0 +1,79 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStorage 本地磁盘文件存储，文件通过 BaseURL 由 HTTP 服务静态托管。
+type LocalStorage struct {
+	root    string
+	baseURL string
+}
+
+// NewLocalStorage 创建本地存储，root 不存在时自动创建
+func NewLocalStorage(root, baseURL string) (*LocalStorage, error) {
+	if root == "" {
+		return nil, errors.New("storage: root is empty")
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("storage: create root: %w", err)
+	}
+	return &LocalStorage{root: root, baseURL: strings.TrimRight(baseURL, "/")}, nil
+}
+
+// Root 返回存储根目录
+func (s *LocalStorage) Root() string { return s.root }
+
+// Put 写入文件并返回访问 URL
+func (s *LocalStorage) Put(_ context.Context, key string, data []byte, _ string) (string, error) {
+	p, err := s.pathOf(key)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return "", fmt.Errorf("storage: create dir: %w", err)
+	}
+
+	// 先写临时文件再重命名，避免读到半个文件
+	tmp := p + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return "", fmt.Errorf("storage: write: %w", err)
+	}
+	if err := os.Rename(tmp, p); err != nil {
+		_ = os.Remove(tmp)
+		return "", fmt.Errorf("storage: rename: %w", err)
+	}
+
+	return s.baseURL + "/" + path.Clean(key), nil
+}
+
+// Delete 删除 URL 对应的文件，非本存储的 URL 或文件不存在时忽略
+func (s *LocalStorage) Delete(_ context.Context, url string) error {
+	key, ok := strings.CutPrefix(url, s.baseURL+"/")
+	if !ok || key == "" {
+		return nil
+	}
+	p, err := s.pathOf(key)
+	if err != nil {
+		return nil
+	}
+	if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("storage: delete: %w", err)
+	}
+	return nil
+}
+
+// pathOf 将 key 转为磁盘路径，拒绝越出根目录的 key
+func (s *LocalStorage) pathOf(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if clean == "/" {
+		return "", errors.New("storage: invalid key")
+	}
+	return filepath.Join(s.root, filepath.FromSlash(clean)), nil
+}
@@ -0,0 +1,40 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+
+	"mygo/internal/requestid"
+)
+
+// ContextHandler 在记录中追加 context 携带的 request_id。
+//
+// 用于只拿到 ctx、无法使用 FromContext 的调用方（如 GORM 的 SQL 日志）；
+// 业务代码应使用 FromContext，其 logger 已带有 request_id，不要再叠加本处理器。
+type ContextHandler struct {
+	slog.Handler
+}
+
+// NewContextHandler 包装 h
+func NewContextHandler(h slog.Handler) *ContextHandler {
+	return &ContextHandler{Handler: h}
+}
+
+// Handle 实现 slog.Handler
+func (h *ContextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := requestid.FromContext(ctx); id != "" {
+		r = r.Clone()
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs 实现 slog.Handler
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup 实现 slog.Handler
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithGroup(name)}
+}
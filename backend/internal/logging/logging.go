@@ -0,0 +1,72 @@
+// Package logging 基于 log/slog 的结构化日志：全局处理器配置、运行时调整级别，
+// 以及随 context 传递的请求级 logger。
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Config 日志配置
+type Config struct {
+	Level  string `yaml:"level"`  // debug, info, warn, error
+	Format string `yaml:"format"` // json, text
+}
+
+// DefaultConfig 返回默认配置：info 级别，JSON 输出便于日志系统采集
+func DefaultConfig() Config {
+	return Config{Level: "info", Format: "json"}
+}
+
+// level 全局日志级别，Setup 创建的处理器共享，SetLevel 可在运行时调整
+var level = new(slog.LevelVar)
+
+// Setup 按配置创建输出到 stdout 的处理器并设为 slog 默认 logger。
+// 标准库 log 的输出随之转为 slog 的 INFO 记录。
+func Setup(cfg Config) error {
+	return setup(os.Stdout, cfg)
+}
+
+func setup(w io.Writer, cfg Config) error {
+	lvl, err := ParseLevel(cfg.Level)
+	if err != nil {
+		return err
+	}
+
+	opts := &slog.HandlerOptions{Level: level}
+	var h slog.Handler
+	switch strings.ToLower(cfg.Format) {
+	case "json":
+		h = slog.NewJSONHandler(w, opts)
+	case "text":
+		h = slog.NewTextHandler(w, opts)
+	default:
+		return fmt.Errorf("logging: unknown format %q", cfg.Format)
+	}
+
+	level.Set(lvl)
+	slog.SetDefault(slog.New(h))
+	return nil
+}
+
+// SetLevel 调整全局日志级别，对已创建的 logger 同样生效
+func SetLevel(s string) error {
+	lvl, err := ParseLevel(s)
+	if err != nil {
+		return err
+	}
+	level.Set(lvl)
+	return nil
+}
+
+// ParseLevel 解析 debug、info、warn、error（不区分大小写）
+func ParseLevel(s string) (slog.Level, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(s)); err != nil {
+		return 0, fmt.Errorf("logging: unknown level %q", s)
+	}
+	return lvl, nil
+}
@@ -0,0 +1,12 @@
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// RegisterDB 注册 database/sql 连接池指标（go_sql_*，db_name 标签区分连接池）
+func RegisterDB(db *sql.DB, name string) error {
+	return Registry.Register(collectors.NewDBStatsCollector(db, name))
+}
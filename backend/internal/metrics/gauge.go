@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// RegisterCachedGauge 注册一个抓取时取值的 gauge，结果缓存 ttl，
+// 用于代价较高的统计（如扫描 Redis 计数）；取值失败时沿用上一次的结果
+func RegisterCachedGauge(name, help string, ttl time.Duration, value func(context.Context) (float64, error)) error {
+	return Registry.Register(&cachedGauge{
+		name:  name,
+		desc:  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", name), help, nil, nil),
+		ttl:   ttl,
+		value: value,
+	})
+}
+
+type cachedGauge struct {
+	name  string
+	desc  *prometheus.Desc
+	ttl   time.Duration
+	value func(context.Context) (float64, error)
+
+	mu      sync.Mutex
+	last    float64
+	updated time.Time
+}
+
+func (g *cachedGauge) Describe(ch chan<- *prometheus.Desc) {
+	ch <- g.desc
+}
+
+func (g *cachedGauge) Collect(ch chan<- prometheus.Metric) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if time.Since(g.updated) >= g.ttl {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		v, err := g.value(ctx)
+		cancel()
+		if err != nil {
+			slog.Warn("metrics: gauge value failed", "metric", g.name, "error", err)
+		} else {
+			g.last, g.updated = v, time.Now()
+		}
+	}
+	ch <- prometheus.MustNewConstMetric(g.desc, prometheus.GaugeValue, g.last)
+}
@@ -0,0 +1,31 @@
+// Package metrics Prometheus 指标：HTTP、数据库连接池、Redis、后台任务与业务指标。
+//
+// 指标注册在独立的 Registry 上，由 bootstrap 在单独的监听地址上通过 Handler 暴露，
+// 不挂在对外的业务端口上。
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace 应用指标的前缀
+const namespace = "mygo"
+
+// Registry 应用指标注册表，包含 Go 运行时与进程指标
+var Registry = prometheus.NewRegistry()
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Handler 返回 /metrics 处理器
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	redisCommandDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "redis_command_duration_seconds",
+		Help:      "Redis command latency; pipelines are recorded once with command=pipeline.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"command"})
+
+	redisCommandErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "redis_command_errors_total",
+		Help:      "Redis commands that failed, excluding missing keys.",
+	}, []string{"command"})
+)
+
+func init() {
+	Registry.MustRegister(redisCommandDuration, redisCommandErrors)
+}
+
+// InstrumentRedis 为客户端添加命令耗时 hook 并注册连接池指标
+func InstrumentRedis(rdb *redis.Client) error {
+	rdb.AddHook(redisHook{})
+	return Registry.Register(&redisPoolCollector{rdb: rdb})
+}
+
+// redisHook 记录命令耗时与错误
+type redisHook struct{}
+
+func (redisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (redisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		observeRedis(strings.ToLower(cmd.Name()), start, err)
+		return err
+	}
+}
+
+func (redisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		observeRedis("pipeline", start, err)
+		return err
+	}
+}
+
+func observeRedis(command string, start time.Time, err error) {
+	redisCommandDuration.WithLabelValues(command).Observe(time.Since(start).Seconds())
+	if err != nil && !errors.Is(err, redis.Nil) {
+		redisCommandErrors.WithLabelValues(command).Inc()
+	}
+}
+
+var (
+	redisPoolHits     = poolDesc("hits_total", "Times a free connection was found in the pool.")
+	redisPoolMisses   = poolDesc("misses_total", "Times a free connection was not found in the pool.")
+	redisPoolTimeouts = poolDesc("timeouts_total", "Times a wait for a connection timed out.")
+	redisPoolTotal    = poolDesc("connections", "Connections currently in the pool.")
+	redisPoolIdle     = poolDesc("idle_connections", "Idle connections currently in the pool.")
+	redisPoolStale    = poolDesc("stale_connections_total", "Stale connections removed from the pool.")
+)
+
+func poolDesc(name, help string) *prometheus.Desc {
+	return prometheus.NewDesc(prometheus.BuildFQName(namespace, "redis_pool", name), help, nil, nil)
+}
+
+// redisPoolCollector 在抓取时读取 PoolStats
+type redisPoolCollector struct {
+	rdb *redis.Client
+}
+
+func (c *redisPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{redisPoolHits, redisPoolMisses, redisPoolTimeouts, redisPoolTotal, redisPoolIdle, redisPoolStale} {
+		ch <- d
+	}
+}
+
+func (c *redisPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.rdb.PoolStats()
+	ch <- prometheus.MustNewConstMetric(redisPoolHits, prometheus.CounterValue, float64(s.Hits))
+	ch <- prometheus.MustNewConstMetric(redisPoolMisses, prometheus.CounterValue, float64(s.Misses))
+	ch <- prometheus.MustNewConstMetric(redisPoolTimeouts, prometheus.CounterValue, float64(s.Timeouts))
+	ch <- prometheus.MustNewConstMetric(redisPoolTotal, prometheus.GaugeValue, float64(s.TotalConns))
+	ch <- prometheus.MustNewConstMetric(redisPoolIdle, prometheus.GaugeValue, float64(s.IdleConns))
+	ch <- prometheus.MustNewConstMetric(redisPoolStale, prometheus.CounterValue, float64(s.StaleConns))
+}
+
+// 确保 redisHook 实现了 redis.Hook 接口
+var _ redis.Hook = redisHook{}
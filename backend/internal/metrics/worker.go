@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	workerJobRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "worker_job_runs_total",
+		Help:      "Background job runs by job name and result (success, failure).",
+	}, []string{"job", "result"})
+
+	workerJobDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "worker_job_duration_seconds",
+		Help:      "Background job run duration.",
+		Buckets:   []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 300},
+	}, []string{"job"})
+
+	workerJobLastSuccess = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "worker_job_last_success_timestamp_seconds",
+		Help:      "Unix time of the last successful run, for staleness alerts.",
+	}, []string{"job"})
+)
+
+func init() {
+	Registry.MustRegister(workerJobRuns, workerJobDuration, workerJobLastSuccess)
+}
+
+// ObserveJob 记录一次后台任务执行
+func ObserveJob(job string, start time.Time, err error) {
+	workerJobDuration.WithLabelValues(job).Observe(time.Since(start).Seconds())
+	if err != nil {
+		workerJobRuns.WithLabelValues(job, "failure").Inc()
+		return
+	}
+	workerJobRuns.WithLabelValues(job, "success").Inc()
+	workerJobLastSuccess.WithLabelValues(job).SetToCurrentTime()
+}
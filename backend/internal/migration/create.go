@@ -0,0 +1,56 @@
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var nameSanitizer = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Create 在 dir 中生成下一个版本的 up/down 空文件，返回生成的文件路径。
+// name 转为小写并以下划线连接，如 "Add user phone" -> 0003_add_user_phone。
+func Create(dir, name string) (up, down string, err error) {
+	name = strings.Trim(nameSanitizer.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return "", "", errors.New("migration name is empty")
+	}
+
+	existing, err := Load(os.DirFS(dir))
+	if err != nil {
+		return "", "", err
+	}
+	var version int64 = 1
+	if n := len(existing); n > 0 {
+		version = existing[n-1].Version + 1
+	}
+
+	base := fmt.Sprintf("%04d_%s", version, name)
+	up = filepath.Join(dir, base+".up.sql")
+	down = filepath.Join(dir, base+".down.sql")
+
+	if err := writeNew(up, fmt.Sprintf("-- %s\n", base)); err != nil {
+		return "", "", err
+	}
+	// 不可回滚的迁移删除 down 文件
+	if err := writeNew(down, fmt.Sprintf("-- 回滚 %s；不可回滚时删除本文件\n", base)); err != nil {
+		return "", "", err
+	}
+	return up, down, nil
+}
+
+// writeNew 创建文件，已存在时报错而不是覆盖
+func writeNew(path, content string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(content); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,396 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// DriftKind 差异类型
+type DriftKind string
+
+const (
+	DriftMissingTable  DriftKind = "missing_table"
+	DriftMissingColumn DriftKind = "missing_column"
+	DriftExtraColumn   DriftKind = "extra_column"
+	DriftType          DriftKind = "type_mismatch"
+	DriftNullable      DriftKind = "nullable_mismatch"
+	DriftMissingIndex  DriftKind = "missing_index"
+	DriftExtraIndex    DriftKind = "extra_index"
+	DriftIndex         DriftKind = "index_mismatch"
+)
+
+// Drift Gorm 模型与数据库实际结构的一处差异
+type Drift struct {
+	Table  string
+	Kind   DriftKind
+	Object string // 列名或索引名，缺表时为空
+	Want   string // 模型期望，多余的列或索引为空
+	Got    string // 数据库实际，缺失的表、列或索引为空
+}
+
+func (d Drift) String() string {
+	s := fmt.Sprintf("%s: %s", d.Table, d.Kind)
+	if d.Object != "" {
+		s += " " + d.Object
+	}
+	if d.Want != "" || d.Got != "" {
+		s += fmt.Sprintf(" (want %q, got %q)", d.Want, d.Got)
+	}
+	return s
+}
+
+// Diff 对比 models 解析出的表结构与当前 schema 中的实际结构，
+// 检查表、列、列类型、是否可空与索引（不含主键），返回按表名排序的差异。
+// 列默认值与外键不参与对比。部分索引的 WHERE 条件按文本比较（去掉外层括号、合并空白），
+// PostgreSQL 会改写条件表达式，写法不同但语义相同时也会报告为 index_mismatch。
+func Diff(ctx context.Context, db *gorm.DB, models []any) ([]Drift, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	var drifts []Drift
+	for _, model := range models {
+		want, err := modelTable(db, model)
+		if err != nil {
+			return nil, err
+		}
+		got, err := inspectTable(ctx, sqlDB, want.name)
+		if err != nil {
+			return nil, fmt.Errorf("inspect %s: %w", want.name, err)
+		}
+		if got == nil {
+			drifts = append(drifts, Drift{Table: want.name, Kind: DriftMissingTable})
+			continue
+		}
+		drifts = append(drifts, diffTable(want, got)...)
+	}
+
+	sort.SliceStable(drifts, func(i, j int) bool { return drifts[i].Table < drifts[j].Table })
+	return drifts, nil
+}
+
+// tableShape 一张表的列与索引
+type tableShape struct {
+	name    string
+	columns map[string]columnShape
+	order   []string // 列顺序，用于稳定输出
+	indexes map[string]indexShape
+}
+
+type columnShape struct {
+	typ      string
+	nullable bool
+}
+
+type indexShape struct {
+	unique  bool
+	columns []string
+	where   string // 部分索引的条件，已经 normalizePredicate 处理
+}
+
+func (i indexShape) String() string {
+	s := "(" + strings.Join(i.columns, ", ") + ")"
+	if i.unique {
+		s = "UNIQUE " + s
+	}
+	if i.where != "" {
+		s += " WHERE " + i.where
+	}
+	return s
+}
+
+func diffTable(want, got *tableShape) []Drift {
+	var drifts []Drift
+	add := func(kind DriftKind, object, w, g string) {
+		drifts = append(drifts, Drift{Table: want.name, Kind: kind, Object: object, Want: w, Got: g})
+	}
+
+	for _, name := range want.order {
+		w := want.columns[name]
+		g, ok := got.columns[name]
+		switch {
+		case !ok:
+			add(DriftMissingColumn, name, w.typ, "")
+		case w.typ != g.typ:
+			add(DriftType, name, w.typ, g.typ)
+		case w.nullable != g.nullable:
+			add(DriftNullable, name, nullability(w.nullable), nullability(g.nullable))
+		}
+	}
+	for _, name := range got.order {
+		if _, ok := want.columns[name]; !ok {
+			add(DriftExtraColumn, name, "", got.columns[name].typ)
+		}
+	}
+
+	for _, name := range sortedKeys(want.indexes) {
+		w := want.indexes[name]
+		g, ok := got.indexes[name]
+		switch {
+		case !ok:
+			add(DriftMissingIndex, name, w.String(), "")
+		case w.String() != g.String():
+			add(DriftIndex, name, w.String(), g.String())
+		}
+	}
+	for _, name := range sortedKeys(got.indexes) {
+		if _, ok := want.indexes[name]; !ok {
+			add(DriftExtraIndex, name, "", got.indexes[name].String())
+		}
+	}
+	return drifts
+}
+
+// modelTable 由 Gorm schema 得到期望的表结构，列类型与 AutoMigrate 建表时一致
+func modelTable(db *gorm.DB, model any) (*tableShape, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return nil, fmt.Errorf("parse %T: %w", model, err)
+	}
+	sch := stmt.Schema
+
+	t := &tableShape{name: sch.Table, columns: map[string]columnShape{}, indexes: map[string]indexShape{}}
+	for _, field := range sch.Fields {
+		// 关联字段没有列名
+		if field.DBName == "" || field.IgnoreMigration {
+			continue
+		}
+		if _, ok := t.columns[field.DBName]; ok {
+			continue
+		}
+		t.columns[field.DBName] = columnShape{
+			typ:      normalizeType(db.Dialector.DataTypeOf(field)),
+			nullable: !field.NotNull && !field.PrimaryKey,
+		}
+		t.order = append(t.order, field.DBName)
+
+		if field.Unique {
+			t.indexes[db.NamingStrategy.UniqueName(sch.Table, field.DBName)] = indexShape{unique: true, columns: []string{field.DBName}}
+		}
+	}
+
+	for _, idx := range sch.ParseIndexes() {
+		shape := indexShape{unique: idx.Class == "UNIQUE", where: normalizePredicate(idx.Where)}
+		for _, opt := range idx.Fields {
+			if opt.Field != nil {
+				shape.columns = append(shape.columns, opt.Field.DBName)
+			} else {
+				shape.columns = append(shape.columns, opt.Expression)
+			}
+		}
+		t.indexes[idx.Name] = shape
+	}
+	return t, nil
+}
+
+// inspectTable 从 information_schema 与 pg_indexes 读取实际结构，表不存在时返回 nil
+func inspectTable(ctx context.Context, db *sql.DB, table string) (*tableShape, error) {
+	rows, err := db.QueryContext(ctx, `
+SELECT column_name, udt_name, character_maximum_length, numeric_precision, numeric_scale,
+       datetime_precision, is_nullable = 'YES', COALESCE(column_default, '')
+FROM information_schema.columns
+WHERE table_schema = current_schema() AND table_name = $1
+ORDER BY ordinal_position`, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	t := &tableShape{name: table, columns: map[string]columnShape{}, indexes: map[string]indexShape{}}
+	for rows.Next() {
+		var (
+			name, udt, def                   string
+			length, precision, scale, dtPrec sql.NullInt64
+			nullable                         bool
+		)
+		if err := rows.Scan(&name, &udt, &length, &precision, &scale, &dtPrec, &nullable, &def); err != nil {
+			return nil, err
+		}
+		t.columns[name] = columnShape{typ: columnType(udt, length, precision, scale, dtPrec, def), nullable: nullable}
+		t.order = append(t.order, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(t.order) == 0 {
+		return nil, nil
+	}
+
+	idxRows, err := db.QueryContext(ctx, `
+SELECT i.indexname, i.indexdef
+FROM pg_indexes i
+JOIN pg_namespace n ON n.nspname = i.schemaname
+JOIN pg_class c ON c.relname = i.indexname AND c.relnamespace = n.oid
+JOIN pg_index x ON x.indexrelid = c.oid
+WHERE i.schemaname = current_schema() AND i.tablename = $1 AND NOT x.indisprimary`, table)
+	if err != nil {
+		return nil, err
+	}
+	defer idxRows.Close()
+
+	for idxRows.Next() {
+		var name, def string
+		if err := idxRows.Scan(&name, &def); err != nil {
+			return nil, err
+		}
+		t.indexes[name] = parseIndexDef(def)
+	}
+	return t, idxRows.Err()
+}
+
+// columnType 把 information_schema 的列信息转为 Gorm DataTypeOf 的写法
+func columnType(udt string, length, precision, scale, dtPrec sql.NullInt64, def string) string {
+	serial := strings.HasPrefix(def, "nextval(")
+	switch udt {
+	case "int2", "int4", "int8":
+		if serial {
+			return map[string]string{"int2": "smallserial", "int4": "serial", "int8": "bigserial"}[udt]
+		}
+		return typeAliases[udt]
+	case "varchar", "bpchar":
+		name := "varchar"
+		if udt == "bpchar" {
+			name = "char"
+		}
+		if length.Valid {
+			return fmt.Sprintf("%s(%d)", name, length.Int64)
+		}
+		return name
+	case "numeric":
+		if precision.Valid {
+			if scale.Valid && scale.Int64 > 0 {
+				return fmt.Sprintf("numeric(%d,%d)", precision.Int64, scale.Int64)
+			}
+			return fmt.Sprintf("numeric(%d)", precision.Int64)
+		}
+		return "numeric"
+	case "timestamptz", "timestamp", "time", "timetz":
+		// 6 为默认精度，Gorm 不写出
+		if dtPrec.Valid && dtPrec.Int64 != 6 {
+			return fmt.Sprintf("%s(%d)", udt, dtPrec.Int64)
+		}
+		return udt
+	}
+	return normalizeType(udt)
+}
+
+// typeAliases PostgreSQL 类型别名到统一写法
+var typeAliases = map[string]string{
+	"int":                         "integer",
+	"int2":                        "smallint",
+	"int4":                        "integer",
+	"int8":                        "bigint",
+	"serial4":                     "serial",
+	"serial8":                     "bigserial",
+	"bool":                        "boolean",
+	"decimal":                     "numeric",
+	"float4":                      "real",
+	"float8":                      "double precision",
+	"character varying":           "varchar",
+	"character":                   "char",
+	"timestamp with time zone":    "timestamptz",
+	"timestamp without time zone": "timestamp",
+}
+
+var typeRe = regexp.MustCompile(`^([a-z0-9_ ]+?)\s*(\(.*\))?$`)
+
+// normalizeType 统一类型写法，如 "character varying(64)" -> "varchar(64)"、"decimal(10, 2)" -> "numeric(10,2)"
+func normalizeType(typ string) string {
+	typ = strings.ToLower(strings.TrimSpace(typ))
+	m := typeRe.FindStringSubmatch(typ)
+	if m == nil {
+		return typ
+	}
+	name, args := m[1], strings.ReplaceAll(m[2], " ", "")
+	if alias, ok := typeAliases[name]; ok {
+		name = alias
+	}
+	return name + args
+}
+
+// parseIndexDef 解析 pg_indexes.indexdef 中的唯一性、列与部分索引条件，如
+// "CREATE UNIQUE INDEX idx_users_email ON public.users USING btree (email) WHERE (deleted_at IS NULL)"
+func parseIndexDef(def string) indexShape {
+	shape := indexShape{unique: strings.HasPrefix(def, "CREATE UNIQUE INDEX ")}
+
+	_, rest, _ := strings.Cut(def, " USING ")
+	open := strings.IndexByte(rest, '(')
+	if open < 0 {
+		return indexShape{columns: []string{def}}
+	}
+	// 按括号深度切分，表达式列中可能含有括号与逗号
+	depth, start := 0, open+1
+	for i := open; i < len(rest); i++ {
+		switch c := rest[i]; {
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				shape.columns = append(shape.columns, trimColumn(rest[start:i]))
+				if _, where, ok := strings.Cut(rest[i+1:], " WHERE "); ok {
+					shape.where = normalizePredicate(where)
+				}
+				return shape
+			}
+		case c == ',' && depth == 1:
+			shape.columns = append(shape.columns, trimColumn(rest[start:i]))
+			start = i + 1
+		}
+	}
+	return indexShape{columns: []string{def}}
+}
+
+// normalizePredicate 去掉包裹整个条件的括号并合并空白，
+// 如 "(deleted_at IS NULL)" 与 "deleted_at  IS NULL" 均为 "deleted_at IS NULL"
+func normalizePredicate(where string) string {
+	where = strings.Join(strings.Fields(where), " ")
+	for strings.HasPrefix(where, "(") && strings.HasSuffix(where, ")") && wrapped(where) {
+		where = strings.TrimSpace(where[1 : len(where)-1])
+	}
+	return where
+}
+
+// wrapped 判断首字符的左括号是否与末尾的右括号配对，排除 "(a) OR (b)"
+func wrapped(s string) bool {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i == len(s)-1
+			}
+		}
+	}
+	return false
+}
+
+func trimColumn(col string) string {
+	return strings.Trim(strings.TrimSpace(col), `"`)
+}
+
+func nullability(nullable bool) string {
+	if nullable {
+		return "NULL"
+	}
+	return "NOT NULL"
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
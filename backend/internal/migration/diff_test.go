@@ -0,0 +1,160 @@
+package migration
+
+import (
+	"database/sql"
+	"slices"
+	"testing"
+)
+
+func TestNormalizeType(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"bigint", "bigint"},
+		{"int8", "bigint"},
+		{"INT4", "integer"},
+		{"character varying(64)", "varchar(64)"},
+		{"varchar(255)", "varchar(255)"},
+		{"decimal(10, 2)", "numeric(10,2)"},
+		{"timestamp with time zone", "timestamptz"},
+		{"timestamptz", "timestamptz"},
+		{"bool", "boolean"},
+		{"float8", "double precision"},
+		{"  Text ", "text"},
+		{"char(64)", "char(64)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := normalizeType(tt.in); got != tt.want {
+				t.Errorf("normalizeType(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColumnType(t *testing.T) {
+	null := sql.NullInt64{}
+	n := func(v int64) sql.NullInt64 { return sql.NullInt64{Int64: v, Valid: true} }
+	tests := []struct {
+		name                     string
+		udt                      string
+		length, precision, scale sql.NullInt64
+		dtPrec                   sql.NullInt64
+		def                      string
+		want                     string
+	}{
+		{"bigserial", "int8", null, n(64), n(0), null, "nextval('users_id_seq'::regclass)", "bigserial"},
+		{"bigint", "int8", null, n(64), n(0), null, "", "bigint"},
+		{"varchar", "varchar", n(64), null, null, null, "", "varchar(64)"},
+		{"char", "bpchar", n(64), null, null, null, "", "char(64)"},
+		{"numeric with scale", "numeric", null, n(10), n(2), null, "", "numeric(10,2)"},
+		{"numeric without scale", "numeric", null, n(10), n(0), null, "", "numeric(10)"},
+		{"timestamptz default precision", "timestamptz", null, null, null, n(6), "", "timestamptz"},
+		{"timestamptz precision 3", "timestamptz", null, null, null, n(3), "", "timestamptz(3)"},
+		{"boolean", "bool", null, null, null, null, "false", "boolean"},
+		{"text", "text", null, null, null, null, "", "text"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := columnType(tt.udt, tt.length, tt.precision, tt.scale, tt.dtPrec, tt.def); got != tt.want {
+				t.Errorf("columnType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseIndexDef(t *testing.T) {
+	tests := []struct {
+		name string
+		def  string
+		want indexShape
+	}{
+		{
+			name: "single column",
+			def:  "CREATE INDEX idx_auth_events_ip ON public.auth_events USING btree (ip)",
+			want: indexShape{columns: []string{"ip"}},
+		},
+		{
+			name: "unique",
+			def:  "CREATE UNIQUE INDEX idx_users_email ON public.users USING btree (email)",
+			want: indexShape{unique: true, columns: []string{"email"}},
+		},
+		{
+			name: "composite",
+			def:  "CREATE INDEX idx_auth_events_user_created ON public.auth_events USING btree (user_id, created_at)",
+			want: indexShape{columns: []string{"user_id", "created_at"}},
+		},
+		{
+			name: "quoted column",
+			def:  `CREATE INDEX idx_t_order ON public.t USING btree ("order")`,
+			want: indexShape{columns: []string{"order"}},
+		},
+		{
+			name: "expression with commas",
+			def:  "CREATE INDEX idx_users_lower ON public.users USING btree (lower((username)::text), COALESCE(email, ''::character varying))",
+			want: indexShape{columns: []string{"lower((username)::text)", "COALESCE(email, ''::character varying)"}},
+		},
+		{
+			name: "partial",
+			def:  "CREATE UNIQUE INDEX idx_users_active ON public.users USING btree (username) WHERE (deleted_at IS NULL)",
+			want: indexShape{unique: true, columns: []string{"username"}, where: "deleted_at IS NULL"},
+		},
+		{
+			name: "unparseable",
+			def:  "CREATE INDEX broken",
+			want: indexShape{columns: []string{"CREATE INDEX broken"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseIndexDef(tt.def)
+			if got.unique != tt.want.unique || !slices.Equal(got.columns, tt.want.columns) || got.where != tt.want.where {
+				t.Errorf("parseIndexDef() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizePredicate(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"deleted_at IS NULL", "deleted_at IS NULL"},
+		{"(deleted_at IS NULL)", "deleted_at IS NULL"},
+		{"((deleted_at  IS\tNULL))", "deleted_at IS NULL"},
+		{"(a IS NULL) OR (b IS NULL)", "(a IS NULL) OR (b IS NULL)"},
+		{"((a IS NULL) OR (b IS NULL))", "(a IS NULL) OR (b IS NULL)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := normalizePredicate(tt.in); got != tt.want {
+				t.Errorf("normalizePredicate(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffTable(t *testing.T) {
+	want := &tableShape{
+		name:    "users",
+		columns: map[string]columnShape{"id": {typ: "bigserial"}, "email": {typ: "varchar(128)"}, "avatar": {typ: "varchar(255)", nullable: true}},
+		order:   []string{"id", "email", "avatar"},
+		indexes: map[string]indexShape{"idx_users_email": {unique: true, columns: []string{"email"}}},
+	}
+	got := &tableShape{
+		name:    "users",
+		columns: map[string]columnShape{"id": {typ: "bigserial"}, "email": {typ: "varchar(64)"}, "legacy": {typ: "text", nullable: true}},
+		order:   []string{"id", "email", "legacy"},
+		indexes: map[string]indexShape{"idx_users_email": {columns: []string{"email"}}, "idx_users_legacy": {columns: []string{"legacy"}}},
+	}
+
+	var kinds []DriftKind
+	for _, d := range diffTable(want, got) {
+		kinds = append(kinds, d.Kind)
+	}
+	wantKinds := []DriftKind{DriftType, DriftMissingColumn, DriftExtraColumn, DriftIndex, DriftExtraIndex}
+	if !slices.Equal(kinds, wantKinds) {
+		t.Errorf("diffTable() kinds = %v, want %v", kinds, wantKinds)
+	}
+}
@@ -0,0 +1,126 @@
+package migration
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func file(s string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(s)} }
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		fsys    fstest.MapFS
+		want    []string // 按顺序的 Migration.String()
+		wantErr string
+	}{
+		{
+			name: "sorted by version, not by file name",
+			fsys: fstest.MapFS{
+				"10_later.up.sql":    file("SELECT 10;"),
+				"0002_second.up.sql": file("SELECT 2;"),
+				"0001_init.up.sql":   file("SELECT 1;"),
+				"0001_init.down.sql": file("SELECT -1;"),
+			},
+			want: []string{"0001_init", "0002_second", "0010_later"},
+		},
+		{
+			name: "non-sql files and directories are ignored",
+			fsys: fstest.MapFS{
+				"0001_init.up.sql":  file("SELECT 1;"),
+				"embed.go":          file("package migrations"),
+				"README.md":         file("docs"),
+				"archive/0009_x.up": file("x"),
+			},
+			want: []string{"0001_init"},
+		},
+		{
+			name:    "invalid file name",
+			fsys:    fstest.MapFS{"0001-init.up.sql": file("SELECT 1;")},
+			wantErr: "invalid migration file name",
+		},
+		{
+			name:    "uppercase name",
+			fsys:    fstest.MapFS{"0001_Init.up.sql": file("SELECT 1;")},
+			wantErr: "invalid migration file name",
+		},
+		{
+			name:    "version zero",
+			fsys:    fstest.MapFS{"0000_init.up.sql": file("SELECT 1;")},
+			wantErr: "invalid migration version",
+		},
+		{
+			name: "duplicate version",
+			fsys: fstest.MapFS{
+				"0001_init.up.sql":  file("SELECT 1;"),
+				"0001_other.up.sql": file("SELECT 1;"),
+			},
+			wantErr: "duplicate migration version 1",
+		},
+		{
+			name:    "down without up",
+			fsys:    fstest.MapFS{"0001_init.down.sql": file("SELECT -1;")},
+			wantErr: "has no up file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Load(tt.fsys)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			var names []string
+			for _, m := range got {
+				names = append(names, m.String())
+			}
+			if strings.Join(names, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Load() = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadChecksum(t *testing.T) {
+	load := func(up, down string) Migration {
+		t.Helper()
+		fsys := fstest.MapFS{"0001_init.up.sql": file(up)}
+		if down != "" {
+			fsys["0001_init.down.sql"] = file(down)
+		}
+		list, err := Load(fsys)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return list[0]
+	}
+
+	base := load("CREATE TABLE t (id bigint);", "")
+	tests := []struct {
+		name     string
+		up, down string
+		same     bool
+	}{
+		{"identical up", "CREATE TABLE t (id bigint);", "", true},
+		{"down file does not affect checksum", "CREATE TABLE t (id bigint);", "DROP TABLE t;", true},
+		{"whitespace change", "CREATE TABLE t (id bigint);\n", "", false},
+		{"content change", "CREATE TABLE t (id integer);", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := load(tt.up, tt.down)
+			if len(m.Checksum) != 64 {
+				t.Fatalf("Checksum = %q, want 64 hex characters", m.Checksum)
+			}
+			if (m.Checksum == base.Checksum) != tt.same {
+				t.Errorf("checksum equal = %v, want %v", m.Checksum == base.Checksum, tt.same)
+			}
+		})
+	}
+}
@@ -0,0 +1,402 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"mygo/internal/logging"
+)
+
+// lockKey 迁移使用的 PostgreSQL advisory lock key（"mygomigr"），保证同一时刻只有一个进程执行迁移
+const lockKey int64 = 0x6d79676f6d696772
+
+const createTableSQL = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version      bigint       PRIMARY KEY,
+    name         varchar(255) NOT NULL,
+    checksum     char(64)     NOT NULL,
+    applied_at   timestamptz  NOT NULL DEFAULT now(),
+    execution_ms bigint       NOT NULL DEFAULT 0,
+    baseline     boolean      NOT NULL DEFAULT false
+)`
+
+// Record schema_migrations 中的一条执行记录
+type Record struct {
+	Version     int64
+	Name        string
+	Checksum    string
+	AppliedAt   time.Time
+	ExecutionMs int64
+	Baseline    bool // 由 baseline 标记，未实际执行 SQL
+}
+
+// Status 一个版本的执行状态
+type Status struct {
+	Version int64
+	Name    string
+	Applied *Record // nil 表示待执行
+	// Missing 已执行但找不到迁移文件，通常是用旧版本程序连接了新版本数据库
+	Missing bool
+	// Modified 迁移文件在执行后被修改
+	Modified bool
+}
+
+// Options 迁移选项
+type Options struct {
+	// DryRun 在同一个事务中执行全部变更后回滚，用于预览
+	DryRun bool
+}
+
+// Migrator 在 PostgreSQL 上执行迁移
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	opts       Options
+}
+
+// New 创建 Migrator，migrations 需按版本号升序（见 Load）
+func New(db *sql.DB, migrations []Migration, opts Options) *Migrator {
+	return &Migrator{db: db, migrations: migrations, opts: opts}
+}
+
+// Migrations 返回全部迁移
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// Up 按版本号顺序执行全部待执行迁移，每个迁移一个事务，返回执行过的迁移。
+// 已执行的迁移文件被修改、找不到，或待执行版本小于已执行的最大版本时不执行任何迁移。
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	return m.run(ctx, m.planUp)
+}
+
+func (m *Migrator) planUp(applied map[int64]Record) ([]step, error) {
+	var latest int64
+	for v := range applied {
+		latest = max(latest, v)
+	}
+	var steps []step
+	for _, mig := range m.migrations {
+		if _, ok := applied[mig.Version]; ok {
+			continue
+		}
+		if mig.Version < latest {
+			return nil, fmt.Errorf("migration %s is older than the latest applied version %d, renumber it", mig, latest)
+		}
+		steps = append(steps, step{migration: mig, action: actionUp})
+	}
+	return steps, nil
+}
+
+// Down 按版本号倒序回滚最近执行的 n 个迁移，返回回滚过的迁移。
+// 其中任一迁移没有 down 文件时不回滚任何迁移。
+func (m *Migrator) Down(ctx context.Context, n int) ([]Migration, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid rollback count %d", n)
+	}
+	return m.run(ctx, m.planDown(n))
+}
+
+func (m *Migrator) planDown(n int) planner {
+	return func(applied map[int64]Record) ([]step, error) {
+		var steps []step
+		for i := len(m.migrations) - 1; i >= 0 && len(steps) < n; i-- {
+			mig := m.migrations[i]
+			if _, ok := applied[mig.Version]; !ok {
+				continue
+			}
+			if !mig.Reversible() {
+				return nil, fmt.Errorf("migration %s is irreversible: no down file", mig)
+			}
+			steps = append(steps, step{migration: mig, action: actionDown})
+		}
+		return steps, nil
+	}
+}
+
+// Baseline 把 version 及之前尚未执行的迁移标记为已执行而不执行 SQL，
+// 用于接管已有表结构的数据库。返回新标记的迁移。
+func (m *Migrator) Baseline(ctx context.Context, version int64) ([]Migration, error) {
+	if !m.has(version) {
+		return nil, fmt.Errorf("migration version %d not found", version)
+	}
+	return m.run(ctx, m.planBaseline(version))
+}
+
+func (m *Migrator) planBaseline(version int64) planner {
+	return func(applied map[int64]Record) ([]step, error) {
+		var steps []step
+		for _, mig := range m.migrations {
+			if mig.Version > version {
+				break
+			}
+			if _, ok := applied[mig.Version]; !ok {
+				steps = append(steps, step{migration: mig, action: actionBaseline})
+			}
+		}
+		return steps, nil
+	}
+}
+
+// Status 返回每个版本的执行状态（含找不到文件的已执行版本），按版本号升序。
+// 只读，不加锁也不创建 schema_migrations 表。
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := loadApplied(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		s := Status{Version: mig.Version, Name: mig.Name}
+		if rec, ok := applied[mig.Version]; ok {
+			s.Applied = &rec
+			s.Modified = rec.Checksum != mig.Checksum
+			delete(applied, mig.Version)
+		}
+		statuses = append(statuses, s)
+	}
+	for _, rec := range applied {
+		statuses = append(statuses, Status{Version: rec.Version, Name: rec.Name, Applied: &rec, Missing: true})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+type action int
+
+const (
+	actionUp action = iota
+	actionDown
+	actionBaseline
+)
+
+func (a action) String() string {
+	switch a {
+	case actionDown:
+		return "down"
+	case actionBaseline:
+		return "baseline"
+	default:
+		return "up"
+	}
+}
+
+// step 计划中的一次迁移操作
+type step struct {
+	migration Migration
+	action    action
+}
+
+// planner 根据已执行记录生成执行计划
+type planner func(applied map[int64]Record) ([]step, error)
+
+// querier *sql.DB、*sql.Conn 与 *sql.Tx 的公共方法
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// run 持有 advisory lock 执行计划。非 dry-run 时每个迁移一个事务；
+// dry-run 时建表与全部迁移在同一个事务中执行，结束后回滚
+func (m *Migrator) run(ctx context.Context, plan planner) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		if m.opts.DryRun {
+			tx, err := conn.BeginTx(ctx, nil)
+			if err != nil {
+				return err
+			}
+			defer tx.Rollback()
+
+			steps, err := m.prepare(ctx, tx, plan)
+			if err != nil {
+				return err
+			}
+			for _, s := range steps {
+				if err := m.apply(ctx, tx, s); err != nil {
+					return err
+				}
+				done = append(done, s.migration)
+			}
+			return nil
+		}
+
+		steps, err := m.prepare(ctx, conn, plan)
+		if err != nil {
+			return err
+		}
+		for _, s := range steps {
+			tx, err := conn.BeginTx(ctx, nil)
+			if err != nil {
+				return err
+			}
+			if err := m.apply(ctx, tx, s); err != nil {
+				tx.Rollback()
+				return err
+			}
+			if err := tx.Commit(); err != nil {
+				return fmt.Errorf("commit migration %s: %w", s.migration, err)
+			}
+			done = append(done, s.migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// prepare 创建 schema_migrations 表，校验已执行记录并生成执行计划
+func (m *Migrator) prepare(ctx context.Context, q querier, plan planner) ([]step, error) {
+	if _, err := q.ExecContext(ctx, createTableSQL); err != nil {
+		return nil, fmt.Errorf("create schema_migrations: %w", err)
+	}
+	applied, err := loadApplied(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.verify(applied); err != nil {
+		return nil, err
+	}
+	return plan(applied)
+}
+
+// verify 已执行的迁移必须仍存在且文件未被修改
+func (m *Migrator) verify(applied map[int64]Record) error {
+	byVersion := make(map[int64]Migration, len(m.migrations))
+	for _, mig := range m.migrations {
+		byVersion[mig.Version] = mig
+	}
+
+	var errs []error
+	for _, rec := range applied {
+		mig, ok := byVersion[rec.Version]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("applied migration %04d_%s not found, the database is newer than this build", rec.Version, rec.Name))
+		case rec.Checksum != mig.Checksum:
+			errs = append(errs, fmt.Errorf("migration %s was modified after it was applied, add a new migration instead", mig))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// apply 在事务中执行一次迁移操作并更新 schema_migrations
+func (m *Migrator) apply(ctx context.Context, tx *sql.Tx, s step) error {
+	mig := s.migration
+	log := logging.FromContext(ctx).With("version", mig.Version, "name", mig.Name, "action", s.action.String())
+	start := time.Now()
+
+	var query string
+	switch s.action {
+	case actionUp:
+		query = mig.Up
+	case actionDown:
+		query = mig.Down
+	}
+	if query != "" {
+		if m.opts.DryRun {
+			log.Info("executing migration", "sql", query)
+		}
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("%s migration %s: %w", s.action, mig, err)
+		}
+	}
+
+	elapsed := time.Since(start)
+	var err error
+	if s.action == actionDown {
+		_, err = tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", mig.Version)
+	} else {
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO schema_migrations (version, name, checksum, execution_ms, baseline) VALUES ($1, $2, $3, $4, $5)",
+			mig.Version, mig.Name, mig.Checksum, elapsed.Milliseconds(), s.action == actionBaseline,
+		)
+	}
+	if err != nil {
+		return fmt.Errorf("record migration %s: %w", mig, err)
+	}
+
+	log.Info("migration applied", "duration_ms", elapsed.Milliseconds())
+	return nil
+}
+
+// withLock 在独占连接上持有 advisory lock 执行 fn，锁已被占用时等待释放
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var locked bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", lockKey).Scan(&locked); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	if !locked {
+		logging.FromContext(ctx).Info("waiting for migration lock held by another process")
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+			return fmt.Errorf("acquire migration lock: %w", err)
+		}
+	}
+	defer func() {
+		// 锁属于会话，连接归还连接池前必须释放
+		if _, err := conn.ExecContext(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", lockKey); err != nil {
+			logging.FromContext(ctx).Warn("release migration lock failed", "error", err)
+		}
+	}()
+
+	return fn(conn)
+}
+
+// loadApplied 读取已执行记录，schema_migrations 表不存在时返回空
+func loadApplied(ctx context.Context, q querier) (map[int64]Record, error) {
+	exists, err := tableExists(ctx, q)
+	if err != nil || !exists {
+		return map[int64]Record{}, err
+	}
+
+	rows, err := q.QueryContext(ctx,
+		"SELECT version, name, checksum, applied_at, execution_ms, baseline FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("load schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]Record)
+	for rows.Next() {
+		var rec Record
+		if err := rows.Scan(&rec.Version, &rec.Name, &rec.Checksum, &rec.AppliedAt, &rec.ExecutionMs, &rec.Baseline); err != nil {
+			return nil, fmt.Errorf("load schema_migrations: %w", err)
+		}
+		applied[rec.Version] = rec
+	}
+	return applied, rows.Err()
+}
+
+func tableExists(ctx context.Context, q querier) (bool, error) {
+	rows, err := q.QueryContext(ctx, "SELECT to_regclass('schema_migrations') IS NOT NULL")
+	if err != nil {
+		return false, fmt.Errorf("check schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	var exists bool
+	if rows.Next() {
+		if err := rows.Scan(&exists); err != nil {
+			return false, err
+		}
+	}
+	return exists, rows.Err()
+}
+
+func (m *Migrator) has(version int64) bool {
+	for _, mig := range m.migrations {
+		if mig.Version == version {
+			return true
+		}
+	}
+	return false
+}
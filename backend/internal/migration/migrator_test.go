@@ -0,0 +1,124 @@
+package migration
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// testMigrations 0001、0002 可回滚，0003 不可回滚
+func testMigrations() []Migration {
+	return []Migration{
+		{Version: 1, Name: "init", Up: "SELECT 1;", Down: "SELECT -1;", Checksum: "c1"},
+		{Version: 2, Name: "second", Up: "SELECT 2;", Down: "SELECT -2;", Checksum: "c2"},
+		{Version: 3, Name: "third", Up: "SELECT 3;", Checksum: "c3"},
+	}
+}
+
+// applied 由版本号构造已执行记录，校验和与 testMigrations 一致
+func applied(versions ...int64) map[int64]Record {
+	records := make(map[int64]Record)
+	for _, v := range versions {
+		for _, m := range testMigrations() {
+			if m.Version == v {
+				records[v] = Record{Version: v, Name: m.Name, Checksum: m.Checksum}
+			}
+		}
+	}
+	return records
+}
+
+func versions(steps []step) []int64 {
+	var vs []int64
+	for _, s := range steps {
+		vs = append(vs, s.migration.Version)
+	}
+	return vs
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name    string
+		applied map[int64]Record
+		wantErr []string
+	}{
+		{"nothing applied", applied(), nil},
+		{"all applied unchanged", applied(1, 2, 3), nil},
+		{
+			name:    "modified after apply",
+			applied: map[int64]Record{1: {Version: 1, Name: "init", Checksum: "other"}},
+			wantErr: []string{"0001_init was modified"},
+		},
+		{
+			name:    "applied version missing from build",
+			applied: map[int64]Record{9: {Version: 9, Name: "future", Checksum: "c9"}},
+			wantErr: []string{"0009_future not found"},
+		},
+		{
+			name: "all problems reported",
+			applied: map[int64]Record{
+				2: {Version: 2, Name: "second", Checksum: "other"},
+				9: {Version: 9, Name: "future", Checksum: "c9"},
+			},
+			wantErr: []string{"0002_second was modified", "0009_future not found"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := New(nil, testMigrations(), Options{}).verify(tt.applied)
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("verify() error = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("verify() error = nil, want %q", tt.wantErr)
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("verify() error = %v, want it to contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPlan(t *testing.T) {
+	m := New(nil, testMigrations(), Options{})
+	tests := []struct {
+		name    string
+		plan    planner
+		applied map[int64]Record
+		want    []int64
+		wantErr string
+	}{
+		{"up from empty", m.planUp, applied(), []int64{1, 2, 3}, ""},
+		{"up pending only", m.planUp, applied(1), []int64{2, 3}, ""},
+		{"up nothing to do", m.planUp, applied(1, 2, 3), nil, ""},
+		{"up rejects gap below latest", m.planUp, applied(1, 3), nil, "0002_second is older than the latest applied version 3"},
+		{"down one", m.planDown(1), applied(1, 2), []int64{2}, ""},
+		{"down in reverse order", m.planDown(2), applied(1, 2), []int64{2, 1}, ""},
+		{"down more than applied", m.planDown(5), applied(1), []int64{1}, ""},
+		{"down irreversible", m.planDown(1), applied(1, 2, 3), nil, "0003_third is irreversible"},
+		{"baseline marks up to version", m.planBaseline(2), applied(), []int64{1, 2}, ""},
+		{"baseline skips applied", m.planBaseline(3), applied(1), []int64{2, 3}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps, err := tt.plan(tt.applied)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("plan error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("plan error = %v", err)
+			}
+			if got := versions(steps); !slices.Equal(got, tt.want) {
+				t.Errorf("plan = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Package requestid 请求 ID 的生成、校验与在 context 中的传递，
+// 用于把同一请求的访问日志、业务日志、SQL 日志与下游调用关联起来。
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// Header 携带请求 ID 的 HTTP 头
+const Header = "X-Request-ID"
+
+// maxLength 接受的客户端请求 ID 最大长度，与审计表 request_id 列一致
+const maxLength = 64
+
+// New 生成 32 位十六进制的随机请求 ID
+func New() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Valid 判断客户端传入的请求 ID 是否可以沿用：长度 1-64，
+// 仅包含字母、数字与 - _ . :，避免日志注入与超长字段
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+type idKey struct{}
+
+// WithID 将请求 ID 写入 context
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, idKey{}, id)
+}
+
+// FromContext 从 context 读取请求 ID，不存在时返回空字符串
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(idKey{}).(string)
+	return id
+}
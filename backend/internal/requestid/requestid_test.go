@@ -0,0 +1,43 @@
+package requestid
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValid(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		want bool
+	}{
+		{"empty", "", false},
+		{"hex", "0123456789abcdef0123456789abcdef", true},
+		{"uuid", "6ba7b810-9dad-11d1-80b4-00c04fd430c8", true},
+		{"punctuation", "trace_01.span:02", true},
+		{"max length", strings.Repeat("a", 64), true},
+		{"too long", strings.Repeat("a", 65), false},
+		{"space", "abc def", false},
+		{"newline", "abc\ninjected=1", false},
+		{"quote", `abc"def`, false},
+		{"slash", "a/b", false},
+		{"non-ascii", "请求", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Valid(tt.id); got != tt.want {
+				t.Errorf("Valid(%q) = %v, want %v", tt.id, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	id := New()
+	if len(id) != 32 || !Valid(id) {
+		t.Fatalf("New() = %q, want 32 hex characters", id)
+	}
+	if New() == id {
+		t.Fatal("New() returned the same id twice")
+	}
+}
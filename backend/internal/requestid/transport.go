@@ -0,0 +1,32 @@
+package requestid
+
+import "net/http"
+
+// Transport 为出站请求附加 context 中的请求 ID，请求已设置该头时保持不变
+type Transport struct {
+	// Base 为 nil 时使用 http.DefaultTransport
+	Base http.RoundTripper
+}
+
+// NewTransport 包装 base
+func NewTransport(base http.RoundTripper) *Transport {
+	return &Transport{Base: base}
+}
+
+// RoundTrip 实现 http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	id := FromContext(req.Context())
+	if id == "" || req.Header.Get(Header) != "" {
+		return base.RoundTrip(req)
+	}
+
+	// RoundTripper 不得修改传入的请求
+	req = req.Clone(req.Context())
+	req.Header.Set(Header, id)
+	return base.RoundTrip(req)
+}
@@ -0,0 +1,46 @@
+package server
+
+import (
+	"net/http"
+
+	"mygo/internal/infra"
+
+	"github.com/gin-gonic/gin"
+)
+
+// probePaths 探针路径，成功时访问日志降为 DEBUG，避免每隔几秒刷屏
+var probePaths = map[string]bool{
+	"/livez":  true,
+	"/readyz": true,
+	"/health": true,
+}
+
+// livez 存活探针：进程能处理请求即返回 200，不检查依赖，
+// 避免数据库故障时编排系统反复重启所有实例
+func livez(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": infra.HealthOK})
+}
+
+// readyz 就绪探针：关键依赖检查全部通过时返回 200，否则 503。
+// 未认证接口，只返回整体状态，不暴露依赖细节
+func readyz(health *infra.HealthRegistry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report := health.Check(c.Request.Context())
+		code := http.StatusOK
+		if !report.Ready() {
+			code = http.StatusServiceUnavailable
+		}
+		c.JSON(code, gin.H{"status": report.Status})
+	}
+}
+
+// healthReport 返回每项检查的详细结果，需要 system:read 权限
+func healthReport(health *infra.HealthRegistry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"code":    0,
+			"message": "success",
+			"data":    health.Check(c.Request.Context()),
+		})
+	}
+}
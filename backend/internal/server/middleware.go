@@ -0,0 +1,92 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"mygo/internal/logging"
+	"mygo/internal/requestid"
+	userHttp "mygo/internal/user/interfaces/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestID 沿用客户端传入的合法 X-Request-ID，否则生成新的，
+// 写入 request context 并在响应头中回传。须紧随链路追踪中间件注册，先于 AccessLog、指标与 Recovery，
+// 使它们都能取得 request id。
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestid.Header)
+		if !requestid.Valid(id) {
+			id = requestid.New()
+		}
+		c.Request = c.Request.WithContext(requestid.WithID(c.Request.Context(), id))
+		c.Header(requestid.Header, id)
+		c.Next()
+	}
+}
+
+// AccessLog 为每个请求创建带 request_id、method、path 的 logger 并注入 request context，
+// 请求结束后输出一条结构化访问日志：5xx 为 ERROR，4xx 为 WARN，其余为 INFO。
+func AccessLog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		path := c.Request.URL.Path
+
+		logger := slog.Default().With(
+			"request_id", requestid.FromContext(c.Request.Context()),
+			"method", c.Request.Method,
+			"path", path,
+		)
+		if sc := trace.SpanContextFromContext(c.Request.Context()); sc.IsValid() {
+			logger = logger.With("trace_id", sc.TraceID().String())
+		}
+		c.Request = c.Request.WithContext(logging.WithLogger(c.Request.Context(), logger))
+
+		c.Next()
+
+		status := c.Writer.Status()
+		attrs := []any{
+			"status", status,
+			"latency_ms", float64(time.Since(start).Microseconds()) / 1000,
+			"ip", c.ClientIP(),
+			"bytes", max(c.Writer.Size(), 0),
+		}
+		if route := c.FullPath(); route != "" && route != path {
+			attrs = append(attrs, "route", route)
+		}
+		if principal, ok := userHttp.PrincipalFrom(c); ok {
+			attrs = append(attrs, "user_id", principal.UserID)
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, "errors", c.Errors.String())
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		case probePaths[path]:
+			level = slog.LevelDebug
+		}
+		logger.Log(c.Request.Context(), level, "request", attrs...)
+	}
+}
+
+// Recovery 捕获 panic，连同调用栈记录到请求 logger 后返回 500。
+// 须注册在 AccessLog 之后，访问日志才能记录到 500 状态。
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(nil, func(c *gin.Context, err any) {
+		logging.FromContext(c.Request.Context()).Error("panic recovered", "panic", err, "stack", string(debug.Stack()))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"code":       500,
+			"message":    "internal server error",
+			"request_id": requestid.FromContext(c.Request.Context()),
+		})
+	})
+}
@@ -1,31 +1,68 @@
 package server
 
 import (
+	"fmt"
+
+	"mygo/internal/infra"
+	"mygo/internal/metrics"
+	userDomain "mygo/internal/user/domain"
 	userHttp "mygo/internal/user/interfaces/http"
 
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 // RouterConfig 路由配置
 type RouterConfig struct {
+	// ServiceName 链路追踪中 HTTP server span 的服务名
+	ServiceName string
+
+	// Health 依赖健康检查，为 nil 时 /readyz 不注册
+	Health *infra.HealthRegistry
+
 	UserHandler *userHttp.Handler
+
+	// UploadDir 非空时以 /uploads 静态托管上传文件
+	UploadDir string
+
+	// TrustedProxies 可信反向代理的 IP 或 CIDR，为空时不信任任何代理，
+	// ClientIP 取连接的对端地址，避免客户端伪造 X-Forwarded-For 绕过按 IP 限流
+	TrustedProxies []string
 }
 
 // NewRouter 创建路由
-func NewRouter(cfg RouterConfig) *gin.Engine {
+func NewRouter(cfg RouterConfig) (*gin.Engine, error) {
 	r := gin.New()
+	// gin 默认信任所有代理，nil 表示不信任
+	if err := r.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("trusted proxies: %w", err)
+	}
 
-	// 全局中间件
-	r.Use(gin.Logger())
-	r.Use(gin.Recovery())
+	// 全局中间件：链路追踪最先执行，之后的日志与指标都能取得 trace id
+	r.Use(otelgin.Middleware(cfg.ServiceName, otelgin.WithGinFilter(func(c *gin.Context) bool {
+		return !probePaths[c.Request.URL.Path]
+	})))
+	r.Use(RequestID())
+	r.Use(AccessLog())
+	r.Use(metrics.HTTPMiddleware())
+	r.Use(Recovery())
 
-	// 健康检查
-	r.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"ok":      true,
-			"message": "service is healthy",
-		})
-	})
+	// 探针：/livez 存活，/readyz 就绪；/health 保留为 /readyz 的别名
+	r.GET("/livez", livez)
+	if cfg.Health != nil {
+		r.GET("/readyz", readyz(cfg.Health))
+		r.GET("/health", readyz(cfg.Health))
+	}
+
+	// JWT 验签公钥
+	if cfg.UserHandler != nil {
+		r.GET("/.well-known/jwks.json", cfg.UserHandler.JWKS)
+	}
+
+	// 上传文件（头像等）
+	if cfg.UploadDir != "" {
+		r.Static("/uploads", cfg.UploadDir)
+	}
 
 	// API 路由组
 	api := r.Group("/api")
@@ -33,6 +70,15 @@ func NewRouter(cfg RouterConfig) *gin.Engine {
 		// 注册各领域模块路由
 		if cfg.UserHandler != nil {
 			userHttp.RegisterRoutes(api, cfg.UserHandler)
+
+			// 健康检查详情
+			if cfg.Health != nil {
+				api.GET("/admin/health",
+					cfg.UserHandler.RequireAuth(),
+					cfg.UserHandler.RequirePermission(userDomain.PermSystemRead),
+					healthReport(cfg.Health),
+				)
+			}
 		}
 	}
 
@@ -53,5 +99,5 @@ func NewRouter(cfg RouterConfig) *gin.Engine {
 		})
 	}
 
-	return r
+	return r, nil
 }
@@ -0,0 +1,99 @@
+// Package tracing OpenTelemetry 链路追踪：TracerProvider 与 W3C trace context 传播的初始化。
+//
+// 各组件的埋点（gin、GORM、go-redis、出站 HTTP）都使用全局 TracerProvider，
+// 因此 Exporter 为 none 时埋点仍在，只是不产生导出的 span。
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// Config 链路追踪配置
+type Config struct {
+	// Exporter none（不导出）、otlp（OTLP/HTTP）、stdout（输出到标准输出，用于本地调试）
+	Exporter string `yaml:"exporter"`
+	// Endpoint OTLP/HTTP 地址，如 http://otel-collector:4318；为空时使用
+	// OTEL_EXPORTER_OTLP_ENDPOINT 或 SDK 默认值 localhost:4318
+	Endpoint string `yaml:"endpoint"`
+	// SampleRatio 根 span 的采样比例 [0, 1]，有上游 trace context 时沿用上游的采样决定
+	SampleRatio float64 `yaml:"sample_ratio"`
+	// ServiceName 上报的 service.name
+	ServiceName string `yaml:"service_name"`
+}
+
+// DefaultConfig 返回默认配置：不导出，全量采样
+func DefaultConfig() Config {
+	return Config{Exporter: "none", SampleRatio: 1, ServiceName: "mygo"}
+}
+
+// Setup 按配置创建 TracerProvider 并设为全局，同时设置 W3C trace context 与 baggage 传播。
+// 返回的 shutdown 在退出时调用，导出缓冲中剩余的 span。
+func Setup(ctx context.Context, cfg Config) (shutdown func(context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		slog.Warn("opentelemetry error", "error", err)
+	}))
+
+	var exporter sdktrace.SpanExporter
+	switch cfg.Exporter {
+	case "none":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		var opts []otlptracehttp.Option
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+		}
+		exporter, err = otlptracehttp.New(ctx, opts...)
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	default:
+		return nil, fmt.Errorf("tracing: unknown exporter %q", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tracing: create %s exporter: %w", cfg.Exporter, err)
+	}
+
+	tp, err := NewProvider(ctx, cfg, sdktrace.WithBatcher(exporter))
+	if err != nil {
+		return nil, err
+	}
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+// NewProvider 创建带服务信息与采样策略的 TracerProvider，span 处理器由调用方提供。
+// 测试中可配合 tracetest.NewInMemoryExporter 与 sdktrace.WithSyncer 断言产生的 span：
+//
+//	exp := tracetest.NewInMemoryExporter()
+//	tp, _ := tracing.NewProvider(ctx, tracing.DefaultConfig(), sdktrace.WithSyncer(exp))
+//	otel.SetTracerProvider(tp)
+func NewProvider(ctx context.Context, cfg Config, opts ...sdktrace.TracerProviderOption) (*sdktrace.TracerProvider, error) {
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(semconv.ServiceName(cfg.ServiceName)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("tracing: build resource: %w", err)
+	}
+
+	opts = append([]sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	}, opts...)
+	return sdktrace.NewTracerProvider(opts...), nil
+}
@@ -0,0 +1,361 @@
+package application
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"mygo/internal/logging"
+	"mygo/internal/user/domain"
+)
+
+const (
+	// DefaultDeletionGracePeriod 注销后可恢复的宽限期
+	DefaultDeletionGracePeriod = 30 * 24 * time.Hour
+
+	// purgeBatchSize 每轮清理的最大用户数
+	purgeBatchSize = 100
+
+	// exportEventPageSize 导出审计日志时的分页大小
+	exportEventPageSize = 200
+)
+
+// DeleteAccount 校验密码后注销账号：软删除用户、清除会话并撤销令牌，
+// 宽限期结束后由后台任务彻底清除。
+func (s *AppService) DeleteAccount(ctx context.Context, userID int64, password string) (*domain.User, error) {
+	if userID == 0 || password == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	user, err := s.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, _, err := s.verifyPassword(password, user.Password)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, domain.ErrIncorrectPassword
+	}
+
+	now := time.Now()
+	purgeAfter := now.Add(s.deletionGracePeriod)
+	if err := s.userRepo.SoftDelete(ctx, userID, purgeAfter); err != nil {
+		return nil, fmt.Errorf("soft delete user: %w", err)
+	}
+
+	if s.sessionCache != nil {
+		if err := s.sessionCache.DeleteByUser(ctx, userID, ""); err != nil {
+			return nil, fmt.Errorf("revoke sessions: %w", err)
+		}
+	}
+	if s.tokenRepo != nil {
+		if err := s.tokenRepo.RevokeAllByUser(ctx, userID, now); err != nil {
+			return nil, fmt.Errorf("revoke tokens: %w", err)
+		}
+	}
+	if err := s.revokeRefreshTokens(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	s.recordEvent(ctx, domain.AuthEvent{
+		UserID:   user.UserID,
+		Username: user.Username,
+		Type:     domain.EventAccountDeleted,
+		Detail:   "purge after " + purgeAfter.UTC().Format(time.RFC3339),
+	})
+
+	user.Password = ""
+	user.DeletedAt = &now
+	user.PurgeAfter = &purgeAfter
+	return user, nil
+}
+
+// RestoreAccount 撤销宽限期内的注销，会话与令牌不会恢复
+func (s *AppService) RestoreAccount(ctx context.Context, userID int64) error {
+	if userID == 0 {
+		return domain.ErrInvalidInput
+	}
+
+	if err := s.userRepo.Restore(ctx, userID); err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	s.recordEvent(ctx, domain.AuthEvent{UserID: user.UserID, Username: user.Username, Type: domain.EventAccountRestored})
+	return nil
+}
+
+// PurgeDeletedUsers 彻底清除宽限期已过的注销用户，返回清除数量。
+// 由后台任务周期调用；单个用户清理失败只记录日志，下一轮重试。
+func (s *AppService) PurgeDeletedUsers(ctx context.Context, now time.Time) (int, error) {
+	users, err := s.userRepo.ListPendingPurge(ctx, now, purgeBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("list pending purge: %w", err)
+	}
+
+	purged := 0
+	for _, user := range users {
+		if err := ctx.Err(); err != nil {
+			return purged, err
+		}
+		if err := s.purgeUser(ctx, user); err != nil {
+			logging.FromContext(ctx).Error("purge user failed", "user_id", user.UserID, "error", err)
+			continue
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// purgeUser 按依赖顺序删除用户拥有的数据，最后删除用户本身
+func (s *AppService) purgeUser(ctx context.Context, user *domain.User) error {
+	for _, provider := range s.dataProviders {
+		if err := provider.Purge(ctx, user.UserID); err != nil {
+			return fmt.Errorf("purge %s: %w", provider.Name(), err)
+		}
+	}
+
+	if user.Avatar != "" && s.fileStorage != nil {
+		if err := s.fileStorage.Delete(ctx, user.Avatar); err != nil {
+			return fmt.Errorf("delete avatar: %w", err)
+		}
+	}
+
+	if s.auditRepo != nil {
+		if err := s.auditRepo.AnonymizeByUser(ctx, user.UserID); err != nil {
+			return fmt.Errorf("anonymize auth events: %w", err)
+		}
+	}
+
+	// 会话在注销时已清除，这里再清一次以防宽限期内残留
+	if s.sessionCache != nil {
+		if err := s.sessionCache.DeleteByUser(ctx, user.UserID, ""); err != nil {
+			return fmt.Errorf("revoke sessions: %w", err)
+		}
+	}
+
+	// 角色与令牌通过外键级联删除
+	if err := s.userRepo.Purge(ctx, user.UserID); err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+		return fmt.Errorf("purge user: %w", err)
+	}
+	return nil
+}
+
+// ExportUserData 导出用户个人数据，返回 ZIP 内容：
+// profile.json、sessions.json、tokens.json、activity.json 以及各模块注册的数据
+func (s *AppService) ExportUserData(ctx context.Context, userID int64) ([]byte, error) {
+	if userID == 0 {
+		return nil, domain.ErrInvalidInput
+	}
+
+	user, err := s.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	if err := writeJSONEntry(zw, "profile.json", newExportProfile(user)); err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.exportSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeJSONEntry(zw, "sessions.json", sessions); err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.exportTokens(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeJSONEntry(zw, "tokens.json", tokens); err != nil {
+		return nil, err
+	}
+
+	events, err := s.exportEvents(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeJSONEntry(zw, "activity.json", events); err != nil {
+		return nil, err
+	}
+
+	for _, provider := range s.dataProviders {
+		data, err := provider.Export(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("export %s: %w", provider.Name(), err)
+		}
+		if err := writeJSONEntry(zw, provider.Name()+".json", data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("close archive: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// exportProfile 导出的用户资料，不含密码哈希
+type exportProfile struct {
+	UserID    int64     `json:"user_id"`
+	Username  string    `json:"username"`
+	Email     string    `json:"email"`
+	Avatar    string    `json:"avatar,omitempty"`
+	Roles     []string  `json:"roles"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newExportProfile(user *domain.User) exportProfile {
+	roles := make([]string, 0, len(user.Roles))
+	for _, r := range user.Roles {
+		roles = append(roles, string(r))
+	}
+	return exportProfile{
+		UserID:    user.UserID,
+		Username:  user.Username,
+		Email:     user.Email,
+		Avatar:    user.Avatar,
+		Roles:     roles,
+		CreatedAt: user.CreatedAt,
+	}
+}
+
+// exportSession 导出的会话，会话 ID 只保留前缀，避免导出文件泄露可用凭证
+type exportSession struct {
+	SessionID string    `json:"session_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (s *AppService) exportSessions(ctx context.Context, userID int64) ([]exportSession, error) {
+	result := []exportSession{}
+	if s.sessionCache == nil {
+		return result, nil
+	}
+
+	sessions, err := s.sessionCache.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list sessions: %w", err)
+	}
+	for id, data := range sessions {
+		result = append(result, exportSession{
+			SessionID: maskSecret(id),
+			CreatedAt: time.Unix(data.CreatedAt, 0).UTC(),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.Before(result[j].CreatedAt) })
+	return result, nil
+}
+
+// exportToken 导出的个人访问令牌，不含哈希
+type exportToken struct {
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func (s *AppService) exportTokens(ctx context.Context, userID int64) ([]exportToken, error) {
+	result := []exportToken{}
+	if s.tokenRepo == nil {
+		return result, nil
+	}
+
+	tokens, err := s.tokenRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list tokens: %w", err)
+	}
+	for _, t := range tokens {
+		scopes := make([]string, 0, len(t.Scopes))
+		for _, p := range t.Scopes {
+			scopes = append(scopes, string(p))
+		}
+		result = append(result, exportToken{
+			Name:       t.Name,
+			Prefix:     t.Prefix,
+			Scopes:     scopes,
+			ExpiresAt:  t.ExpiresAt,
+			LastUsedAt: t.LastUsedAt,
+			RevokedAt:  t.RevokedAt,
+			CreatedAt:  t.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// exportEvent 导出的审计事件
+type exportEvent struct {
+	Type      string    `json:"type"`
+	Detail    string    `json:"detail,omitempty"`
+	IP        string    `json:"ip,omitempty"`
+	UserAgent string    `json:"user_agent,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (s *AppService) exportEvents(ctx context.Context, userID int64) ([]exportEvent, error) {
+	result := []exportEvent{}
+	if s.auditRepo == nil {
+		return result, nil
+	}
+
+	filter := domain.AuthEventFilter{UserID: userID, Limit: exportEventPageSize}
+	for {
+		events, total, err := s.auditRepo.List(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("list auth events: %w", err)
+		}
+		for _, e := range events {
+			result = append(result, exportEvent{
+				Type:      string(e.Type),
+				Detail:    e.Detail,
+				IP:        e.IP,
+				UserAgent: e.UserAgent,
+				CreatedAt: e.CreatedAt,
+			})
+		}
+		filter.Offset += len(events)
+		if len(events) == 0 || int64(filter.Offset) >= total {
+			return result, nil
+		}
+	}
+}
+
+// writeJSONEntry 向 ZIP 写入一个 JSON 文件
+func writeJSONEntry(zw *zip.Writer, name string, v any) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", name, err)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("encode %s: %w", name, err)
+	}
+	return nil
+}
+
+// maskSecret 只保留前 8 个字符
+func maskSecret(s string) string {
+	if len(s) <= 8 {
+		return s
+	}
+	return s[:8] + "…"
+}
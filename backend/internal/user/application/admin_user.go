@@ -0,0 +1,180 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"mygo/internal/user/domain"
+)
+
+// ListUsers 按条件分页查询用户
+func (s *AppService) ListUsers(ctx context.Context, filter domain.UserFilter) ([]*domain.User, int64, error) {
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedFrom.Before(*filter.CreatedTo) {
+		return nil, 0, domain.ErrInvalidInput
+	}
+	if filter.Role != "" {
+		if _, err := domain.ParseRole(string(filter.Role)); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	users, total, err := s.userRepo.List(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, u := range users {
+		u.Password = ""
+	}
+	return users, total, nil
+}
+
+// GetUserDetail 查看用户及其当前会话，会话 ID 只返回前缀
+func (s *AppService) GetUserDetail(ctx context.Context, userID int64) (*domain.User, []domain.SessionSummary, error) {
+	if userID == 0 {
+		return nil, nil, domain.ErrInvalidInput
+	}
+
+	user, err := s.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	user.Password = ""
+
+	sessions := []domain.SessionSummary{}
+	if s.sessionCache != nil {
+		active, err := s.sessionCache.ListByUser(ctx, userID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("list sessions: %w", err)
+		}
+		for id, data := range active {
+			sessions = append(sessions, domain.SessionSummary{
+				IDPrefix:  maskSecret(id),
+				CreatedAt: time.Unix(data.CreatedAt, 0),
+			})
+		}
+		sort.Slice(sessions, func(i, j int) bool { return sessions[i].CreatedAt.After(sessions[j].CreatedAt) })
+	}
+	return user, sessions, nil
+}
+
+// DisableUser 停用用户：禁止登录，清除会话与刷新令牌。
+// 个人访问令牌保留但在认证时被拒绝，重新启用后恢复可用。
+func (s *AppService) DisableUser(ctx context.Context, userID int64, reason string) error {
+	if userID == 0 || len(reason) > 255 {
+		return domain.ErrInvalidInput
+	}
+	// 防止管理员把自己锁在外面
+	if userID == domain.RequestMetaFrom(ctx).ActorID {
+		return domain.ErrInvalidInput
+	}
+
+	user, err := s.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.IsDisabled() {
+		now := time.Now()
+		user.DisabledAt = &now
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return fmt.Errorf("update user: %w", err)
+		}
+	}
+
+	if err := s.revokeUserAccess(ctx, userID); err != nil {
+		return err
+	}
+
+	s.recordEvent(ctx, domain.AuthEvent{
+		UserID:   user.UserID,
+		Username: user.Username,
+		Type:     domain.EventUserDisabled,
+		Detail:   reason,
+	})
+	return nil
+}
+
+// EnableUser 重新启用用户
+func (s *AppService) EnableUser(ctx context.Context, userID int64) error {
+	if userID == 0 {
+		return domain.ErrInvalidInput
+	}
+
+	user, err := s.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.IsDisabled() {
+		return nil
+	}
+
+	user.DisabledAt = nil
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("update user: %w", err)
+	}
+
+	s.recordEvent(ctx, domain.AuthEvent{UserID: user.UserID, Username: user.Username, Type: domain.EventUserEnabled})
+	return nil
+}
+
+// ForcePasswordReset 要求用户修改密码：清除会话与刷新令牌，
+// 用户重新登录后在修改密码前只能访问有限接口。
+func (s *AppService) ForcePasswordReset(ctx context.Context, userID int64) error {
+	if userID == 0 {
+		return domain.ErrInvalidInput
+	}
+
+	user, err := s.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.MustChangePassword {
+		user.MustChangePassword = true
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return fmt.Errorf("update user: %w", err)
+		}
+	}
+
+	if err := s.revokeUserAccess(ctx, userID); err != nil {
+		return err
+	}
+
+	s.recordEvent(ctx, domain.AuthEvent{UserID: user.UserID, Username: user.Username, Type: domain.EventPasswordReset})
+	return nil
+}
+
+// MarkEmailVerified 将用户当前邮箱标记为已验证。在邮箱验证流程上线前由管理员人工确认，
+// 用户修改邮箱后标记自动清除
+func (s *AppService) MarkEmailVerified(ctx context.Context, userID int64) error {
+	if userID == 0 {
+		return domain.ErrInvalidInput
+	}
+
+	user, err := s.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	now := time.Now()
+	user.EmailVerifiedAt = &now
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("update user: %w", err)
+	}
+
+	s.recordEvent(ctx, domain.AuthEvent{UserID: user.UserID, Username: user.Username, Type: domain.EventEmailVerified, Detail: user.Email})
+	return nil
+}
+
+// revokeUserAccess 清除用户的全部会话与刷新令牌
+func (s *AppService) revokeUserAccess(ctx context.Context, userID int64) error {
+	if s.sessionCache != nil {
+		if err := s.sessionCache.DeleteByUser(ctx, userID, ""); err != nil {
+			return fmt.Errorf("revoke sessions: %w", err)
+		}
+	}
+	return s.revokeRefreshTokens(ctx, userID)
+}
@@ -6,11 +6,12 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"mygo/internal/logging"
 	"mygo/internal/user/domain"
-
-	"golang.org/x/crypto/bcrypt"
 )
 
 // AppService 用户应用服务（用例层实现）
@@ -18,29 +19,205 @@ import (
 type AppService struct {
 	userRepo     domain.UserRepository
 	sessionCache domain.SessionCache
+	tokenRepo    domain.TokenRepository
+	loginLimiter domain.LoginLimiter
+	fileStorage  domain.FileStorage
+	idGen        domain.IDGenerator
+	hasher       domain.PasswordHasher
+	// dummyHash 用户不存在时用于校验的固定哈希，使其耗时与真实用户一致，避免按响应时间枚举用户名
+	dummyHash func() (string, error)
+
+	breachChecker domain.BreachedPasswordChecker
+
+	// policySnap 注册模式、保留用户名与密码策略，支持热更新，见 settings.go
+	policySnap atomic.Pointer[policySnapshot]
+
+	auditRepo domain.AuthEventRepository
+
+	dataProviders       []domain.UserDataProvider
+	deletionGracePeriod time.Duration
+
+	inviteRepo domain.InvitationRepository
+
+	accessIssuer domain.AccessTokenIssuer
+	refreshStore domain.RefreshTokenStore
+	refreshTTL   time.Duration
+}
+
+// Option 可选依赖
+type Option func(*AppService)
+
+// WithTokenRepository 启用个人访问令牌
+func WithTokenRepository(repo domain.TokenRepository) Option {
+	return func(s *AppService) { s.tokenRepo = repo }
+}
+
+// WithLoginLimiter 启用登录失败限流
+func WithLoginLimiter(limiter domain.LoginLimiter) Option {
+	return func(s *AppService) { s.loginLimiter = limiter }
+}
+
+// WithFileStorage 启用头像上传
+func WithFileStorage(storage domain.FileStorage) Option {
+	return func(s *AppService) { s.fileStorage = storage }
+}
+
+// WithIDGenerator 设置用户 ID 生成器
+func WithIDGenerator(gen domain.IDGenerator) Option {
+	return func(s *AppService) { s.idGen = gen }
+}
+
+// WithPasswordHasher 设置密码哈希实现
+func WithPasswordHasher(hasher domain.PasswordHasher) Option {
+	return func(s *AppService) { s.hasher = hasher }
+}
+
+// WithPasswordPolicy 设置密码策略，默认 domain.DefaultPasswordPolicy
+func WithPasswordPolicy(policy domain.PasswordPolicy) Option {
+	return func(s *AppService) {
+		s.updatePolicy(func(p *policySnapshot) { p.passwordPolicy = policy })
+	}
+}
+
+// WithReservedUsernames 设置注册与改名时禁止使用的用户名，默认 domain.DefaultReservedUsernames
+func WithReservedUsernames(names []string) Option {
+	return func(s *AppService) {
+		s.updatePolicy(func(p *policySnapshot) { p.reservedNames = domain.NewReservedNames(names) })
+	}
+}
+
+// WithBreachedPasswordChecker 启用泄露密码检查
+func WithBreachedPasswordChecker(checker domain.BreachedPasswordChecker) Option {
+	return func(s *AppService) { s.breachChecker = checker }
+}
+
+// WithAuthEventRepository 启用认证审计日志
+func WithAuthEventRepository(repo domain.AuthEventRepository) Option {
+	return func(s *AppService) { s.auditRepo = repo }
+}
+
+// WithUserDataProviders 注册其他模块持有的用户数据，参与导出与注销清理
+func WithUserDataProviders(providers ...domain.UserDataProvider) Option {
+	return func(s *AppService) { s.dataProviders = append(s.dataProviders, providers...) }
+}
+
+// WithDeletionGracePeriod 设置注销宽限期，默认 DefaultDeletionGracePeriod
+func WithDeletionGracePeriod(d time.Duration) Option {
+	return func(s *AppService) {
+		if d > 0 {
+			s.deletionGracePeriod = d
+		}
+	}
+}
+
+// WithRegistrationMode 设置注册模式，默认 open
+func WithRegistrationMode(mode domain.RegistrationMode) Option {
+	return func(s *AppService) {
+		s.updatePolicy(func(p *policySnapshot) { p.registrationMode = mode })
+	}
+}
+
+// WithInvitationRepository 启用注册邀请
+func WithInvitationRepository(repo domain.InvitationRepository) Option {
+	return func(s *AppService) { s.inviteRepo = repo }
+}
+
+// WithJWT 启用 JWT 访问令牌与刷新令牌
+func WithJWT(issuer domain.AccessTokenIssuer, store domain.RefreshTokenStore) Option {
+	return func(s *AppService) {
+		s.accessIssuer = issuer
+		s.refreshStore = store
+	}
+}
+
+// WithRefreshTokenTTL 设置刷新令牌有效期，默认 DefaultRefreshTokenTTL
+func WithRefreshTokenTTL(d time.Duration) Option {
+	return func(s *AppService) {
+		if d > 0 {
+			s.refreshTTL = d
+		}
+	}
 }
 
 // NewAppService 构造函数
-func NewAppService(userRepo domain.UserRepository, sessionCache domain.SessionCache) *AppService {
-	return &AppService{
+func NewAppService(userRepo domain.UserRepository, sessionCache domain.SessionCache, opts ...Option) *AppService {
+	s := &AppService{
 		userRepo:     userRepo,
 		sessionCache: sessionCache,
+
+		deletionGracePeriod: DefaultDeletionGracePeriod,
+		refreshTTL:          DefaultRefreshTokenTTL,
+	}
+	s.UpdateSettings(Settings{
+		RegistrationMode:  domain.RegistrationOpen,
+		ReservedUsernames: domain.DefaultReservedUsernames,
+		PasswordPolicy:    domain.DefaultPasswordPolicy(),
+	})
+	for _, opt := range opts {
+		opt(s)
 	}
+	s.dummyHash = sync.OnceValues(func() (string, error) {
+		return s.hashPassword("mygo-dummy-password")
+	})
+	return s
 }
 
-// Register 用户注册
-func (s *AppService) Register(ctx context.Context, username, email, password string) (*domain.User, error) {
-	if username == "" || email == "" || password == "" {
-		return nil, domain.ErrInvalidInput
+// Register 用户注册。invite 模式下需要有效邀请码，closed 模式下拒绝注册。
+func (s *AppService) Register(ctx context.Context, cmd domain.RegisterCommand) (*domain.User, error) {
+	mode := s.policy().registrationMode
+	if mode == domain.RegistrationClosed {
+		return nil, domain.ErrRegistrationClosed
+	}
+	if err := s.validateRegistration(ctx, cmd.Username, cmd.Email, cmd.Password, false); err != nil {
+		return nil, err
+	}
+
+	if mode == domain.RegistrationInvite {
+		inv, err := s.consumeInvitation(ctx, cmd.InviteCode)
+		if err != nil {
+			return nil, err
+		}
+
+		user, err := s.createUser(ctx, cmd.Username, cmd.Email, cmd.Password, inv.CreatedBy)
+		if err != nil {
+			// 注册失败时归还使用次数
+			if relErr := s.inviteRepo.Release(context.WithoutCancel(ctx), inv.ID); relErr != nil {
+				logging.FromContext(ctx).Error("release invitation failed", "invitation_id", inv.ID, "error", relErr)
+			}
+			return nil, err
+		}
+		s.recordEvent(ctx, domain.AuthEvent{
+			UserID:   user.UserID,
+			Username: user.Username,
+			Type:     domain.EventUserRegistered,
+			Detail:   fmt.Sprintf("invitation %d (%s) from user %d", inv.ID, inv.Prefix, inv.CreatedBy),
+		})
+		return user, nil
+	}
+
+	user, err := s.createUser(ctx, cmd.Username, cmd.Email, cmd.Password, 0)
+	if err != nil {
+		return nil, err
 	}
+	s.recordEvent(ctx, domain.AuthEvent{UserID: user.UserID, Username: user.Username, Type: domain.EventUserRegistered})
+	return user, nil
+}
 
-	// 检查用户名是否已存在
+// RegistrationMode 当前注册模式
+func (s *AppService) RegistrationMode() domain.RegistrationMode {
+	return s.policy().registrationMode
+}
+
+// createUser 检查唯一性、哈希密码并创建用户，不受注册模式限制。
+// 预先查重只为返回更明确的错误，并发注册时由唯一索引兜底，同样返回 ConflictError。
+func (s *AppService) createUser(ctx context.Context, username, email, password string, invitedBy int64) (*domain.User, error) {
+	// 检查用户名是否已存在（按规范形式比较，见 domain.CanonicalUsername）
 	existing, err := s.userRepo.GetByUsername(ctx, username)
 	if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
 		return nil, fmt.Errorf("check username: %w", err)
 	}
 	if existing != nil {
-		return nil, domain.ErrUserAlreadyExists
+		return nil, &domain.ConflictError{Field: "username"}
 	}
 
 	// 检查邮箱是否已存在
@@ -49,23 +226,30 @@ func (s *AppService) Register(ctx context.Context, username, email, password str
 		return nil, fmt.Errorf("check email: %w", err)
 	}
 	if existing != nil {
-		return nil, domain.ErrUserAlreadyExists
+		return nil, &domain.ConflictError{Field: "email"}
 	}
 
 	// 密码加密
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashedPassword, err := s.hashPassword(password)
 	if err != nil {
-		return nil, fmt.Errorf("hash password: %w", err)
+		return nil, err
 	}
 
-	// 生成用户 ID (简单实现，生产环境应使用雪花算法等)
-	userID := time.Now().UnixNano()
+	// 生成用户 ID
+	if s.idGen == nil {
+		return nil, errors.New("id generator is not configured")
+	}
+	userID, err := s.idGen.NextID()
+	if err != nil {
+		return nil, fmt.Errorf("generate user id: %w", err)
+	}
 
 	user := &domain.User{
-		UserID:   userID,
-		Username: username,
-		Email:    email,
-		Password: string(hashedPassword),
+		UserID:    userID,
+		Username:  username,
+		Email:     email,
+		Password:  hashedPassword,
+		InvitedBy: invitedBy,
 	}
 
 	if err := s.userRepo.Create(ctx, user); err != nil {
@@ -81,18 +265,9 @@ func (s *AppService) Login(ctx context.Context, username, password string) (stri
 		return "", nil, domain.ErrInvalidInput
 	}
 
-	// 查找用户
-	user, err := s.userRepo.GetByUsername(ctx, username)
+	user, err := s.verifyCredentials(ctx, username, password)
 	if err != nil {
-		if errors.Is(err, domain.ErrUserNotFound) {
-			return "", nil, domain.ErrInvalidCredentials
-		}
-		return "", nil, fmt.Errorf("get user: %w", err)
-	}
-
-	// 验证密码
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
-		return "", nil, domain.ErrInvalidCredentials
+		return "", nil, err
 	}
 
 	// 生成会话 ID
@@ -113,18 +288,176 @@ func (s *AppService) Login(ctx context.Context, username, password string) (stri
 		}
 	}
 
+	s.recordEvent(ctx, domain.AuthEvent{UserID: user.UserID, Username: user.Username, Type: domain.EventLoginSucceeded})
 	return sessionID, user, nil
 }
 
+// verifyCredentials 校验用户名密码，并维护登录失败计数
+func (s *AppService) verifyCredentials(ctx context.Context, username, password string) (*domain.User, error) {
+	ip := domain.RequestMetaFrom(ctx).IP
+	// 失败计数按规范用户名统计，避免通过大小写变体绕过锁定
+	limiterKey := domain.CanonicalUsername(username)
+
+	if s.loginLimiter != nil {
+		retryAfter, err := s.loginLimiter.Check(ctx, limiterKey, ip)
+		if err != nil {
+			return nil, fmt.Errorf("check login limit: %w", err)
+		}
+		if retryAfter > 0 {
+			err := &domain.LockoutError{RetryAfter: retryAfter}
+			s.recordEvent(ctx, domain.AuthEvent{Username: username, Type: domain.EventLoginFailed, Detail: err.Error()})
+			return nil, err
+		}
+	}
+
+	// 查找用户并验证密码，用户不存在与密码错误同样计入失败
+	user, err := s.userRepo.GetByUsername(ctx, username)
+	if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	if user == nil {
+		// 同样执行一次哈希校验，使用户不存在与密码错误的响应耗时一致
+		if dummy, err := s.dummyHash(); err == nil {
+			_, _, _ = s.verifyPassword(password, dummy)
+		}
+		return nil, s.recordLoginFailure(ctx, username, limiterKey, ip, 0)
+	}
+	ok, needsRehash, err := s.verifyPassword(password, user.Password)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, s.recordLoginFailure(ctx, username, limiterKey, ip, user.UserID)
+	}
+	if user.IsDisabled() {
+		s.recordEvent(ctx, domain.AuthEvent{
+			UserID:   user.UserID,
+			Username: user.Username,
+			Type:     domain.EventLoginFailed,
+			Detail:   domain.ErrUserDisabled.Error(),
+		})
+		return nil, domain.ErrUserDisabled
+	}
+
+	if s.loginLimiter != nil {
+		if err := s.loginLimiter.Reset(ctx, limiterKey); err != nil {
+			return nil, fmt.Errorf("reset login limit: %w", err)
+		}
+	}
+
+	if needsRehash {
+		s.rehashPassword(ctx, user, password)
+	}
+	return user, nil
+}
+
+// rehashPassword 使用当前首选算法重新哈希密码，失败不影响登录
+func (s *AppService) rehashPassword(ctx context.Context, user *domain.User, password string) {
+	hashed, err := s.hashPassword(password)
+	if err != nil {
+		logging.FromContext(ctx).Warn("rehash password failed", "user_id", user.UserID, "error", err)
+		return
+	}
+
+	old := user.Password
+	user.Password = hashed
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		user.Password = old
+		logging.FromContext(ctx).Warn("rehash password failed", "user_id", user.UserID, "error", err)
+	}
+}
+
+// hashPassword 哈希密码
+func (s *AppService) hashPassword(password string) (string, error) {
+	if s.hasher == nil {
+		return "", errors.New("password hasher is not configured")
+	}
+	hashed, err := s.hasher.Hash(password)
+	if err != nil {
+		return "", fmt.Errorf("hash password: %w", err)
+	}
+	return hashed, nil
+}
+
+// verifyPassword 校验密码
+func (s *AppService) verifyPassword(password, encoded string) (ok, needsRehash bool, err error) {
+	if s.hasher == nil {
+		return false, false, errors.New("password hasher is not configured")
+	}
+	ok, needsRehash, err = s.hasher.Verify(password, encoded)
+	if err != nil {
+		return false, false, fmt.Errorf("verify password: %w", err)
+	}
+	return ok, needsRehash, nil
+}
+
+// recordLoginFailure 记录失败；触发锁定时返回 LockoutError，否则返回 ErrInvalidCredentials。
+// userID 为 0 表示用户名不存在。
+func (s *AppService) recordLoginFailure(ctx context.Context, username, limiterKey, ip string, userID int64) error {
+	var result error = domain.ErrInvalidCredentials
+	if s.loginLimiter != nil {
+		lockout, err := s.loginLimiter.RecordFailure(ctx, limiterKey, ip)
+		if err != nil {
+			return fmt.Errorf("record login failure: %w", err)
+		}
+		if lockout > 0 {
+			result = &domain.LockoutError{RetryAfter: lockout}
+		}
+	}
+
+	s.recordEvent(ctx, domain.AuthEvent{
+		UserID:   userID,
+		Username: username,
+		Type:     domain.EventLoginFailed,
+		Detail:   result.Error(),
+	})
+	return result
+}
+
+// UnlockUser 解除用户的登录锁定
+func (s *AppService) UnlockUser(ctx context.Context, userID int64) error {
+	if userID == 0 {
+		return domain.ErrInvalidInput
+	}
+
+	user, err := s.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if s.loginLimiter == nil {
+		return nil
+	}
+	if err := s.loginLimiter.Reset(ctx, domain.CanonicalUsername(user.Username)); err != nil {
+		return err
+	}
+
+	s.recordEvent(ctx, domain.AuthEvent{UserID: user.UserID, Username: user.Username, Type: domain.EventUserUnlocked})
+	return nil
+}
+
 // Logout 用户登出
 func (s *AppService) Logout(ctx context.Context, sessionID string) error {
 	if sessionID == "" {
 		return domain.ErrInvalidInput
 	}
 
-	if s.sessionCache != nil {
-		return s.sessionCache.Delete(ctx, sessionID)
+	if s.sessionCache == nil {
+		return nil
+	}
+
+	session, err := s.sessionCache.Get(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return nil
+		}
+		return fmt.Errorf("get session: %w", err)
+	}
+	if err := s.sessionCache.Delete(ctx, sessionID); err != nil {
+		return err
 	}
+
+	s.recordEvent(ctx, domain.AuthEvent{UserID: session.UserID, Username: session.Username, Type: domain.EventLogout})
 	return nil
 }
 
@@ -144,6 +477,47 @@ func (s *AppService) GetUserByID(ctx context.Context, id int64) (*domain.User, e
 	return user, nil
 }
 
+// Authenticate 根据会话 ID 解析访问主体，并续期会话
+func (s *AppService) Authenticate(ctx context.Context, sessionID string) (*domain.Principal, error) {
+	if sessionID == "" || s.sessionCache == nil {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	session, err := s.sessionCache.Get(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return nil, domain.ErrUnauthenticated
+		}
+		return nil, fmt.Errorf("get session: %w", err)
+	}
+
+	// 角色每次从仓储读取，撤销后立即生效
+	user, err := s.userRepo.GetByUserID(ctx, session.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.ErrUnauthenticated
+		}
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	if user.IsDisabled() {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	if err := s.sessionCache.Refresh(ctx, sessionID); err != nil {
+		return nil, fmt.Errorf("refresh session: %w", err)
+	}
+
+	return &domain.Principal{
+		UserID:    user.UserID,
+		Username:  user.Username,
+		Roles:     user.Roles,
+		SessionID: sessionID,
+
+		MustChangePassword: user.MustChangePassword,
+	}, nil
+}
+
 // generateSessionID 生成会话 ID
 func generateSessionID() (string, error) {
 	bytes := make([]byte, 32)
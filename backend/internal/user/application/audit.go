@@ -0,0 +1,42 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"mygo/internal/logging"
+	"mygo/internal/user/domain"
+)
+
+// recordEvent 追加一条审计事件，请求来源信息取自 context。
+// 审计写入失败只记录日志，不影响业务结果。
+func (s *AppService) recordEvent(ctx context.Context, event domain.AuthEvent) {
+	if s.auditRepo == nil {
+		return
+	}
+
+	meta := domain.RequestMetaFrom(ctx)
+	event.IP = meta.IP
+	event.UserAgent = meta.UserAgent
+	event.RequestID = meta.RequestID
+	if event.ActorID == 0 {
+		event.ActorID = meta.ActorID
+	}
+	event.CreatedAt = time.Now()
+
+	// 请求被取消时仍需落库
+	if err := s.auditRepo.Append(context.WithoutCancel(ctx), &event); err != nil {
+		logging.FromContext(ctx).Error("append auth event failed", "event", event.Type, "user_id", event.UserID, "error", err)
+	}
+}
+
+// ListAuthEvents 查询认证审计事件
+func (s *AppService) ListAuthEvents(ctx context.Context, filter domain.AuthEventFilter) ([]*domain.AuthEvent, int64, error) {
+	if s.auditRepo == nil {
+		return nil, 0, nil
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, 0, domain.ErrInvalidInput
+	}
+	return s.auditRepo.List(ctx, filter)
+}
@@ -0,0 +1,129 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"  // 注册 GIF 解码器
+	_ "image/jpeg" // 注册 JPEG 解码器
+	"image/png"
+	"io"
+	"net/http"
+
+	"mygo/internal/user/domain"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // 注册 WebP 解码器
+)
+
+const (
+	// MaxAvatarBytes 头像上传大小上限
+	MaxAvatarBytes = 2 << 20
+
+	// 解码前校验像素尺寸，防止解压炸弹
+	maxAvatarPixels = 4096
+	// avatarSize 输出头像边长
+	avatarSize = 256
+)
+
+// allowedAvatarTypes 允许的头像类型（按内容嗅探，而非客户端声明）
+var allowedAvatarTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// UploadAvatar 上传头像：嗅探类型、限制大小、居中裁剪缩放为正方形 PNG 后存储
+func (s *AppService) UploadAvatar(ctx context.Context, userID int64, r io.Reader) (*domain.User, error) {
+	if s.fileStorage == nil {
+		return nil, errors.New("file storage is not configured")
+	}
+	if userID == 0 || r == nil {
+		return nil, domain.ErrInvalidInput
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, MaxAvatarBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("read avatar: %w", err)
+	}
+	if len(data) > MaxAvatarBytes {
+		return nil, domain.ErrAvatarTooLarge
+	}
+
+	encoded, err := processAvatar(data)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, fmt.Errorf("generate avatar key: %w", err)
+	}
+	key := fmt.Sprintf("avatars/%d/%s.png", userID, hex.EncodeToString(suffix))
+
+	url, err := s.fileStorage.Put(ctx, key, encoded, "image/png")
+	if err != nil {
+		return nil, fmt.Errorf("store avatar: %w", err)
+	}
+
+	oldAvatar := user.Avatar
+	user.Avatar = url
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		_ = s.fileStorage.Delete(ctx, url)
+		return nil, fmt.Errorf("update user: %w", err)
+	}
+
+	if oldAvatar != "" {
+		// 旧头像删除失败不影响本次结果
+		_ = s.fileStorage.Delete(ctx, oldAvatar)
+	}
+
+	user.Password = ""
+	return user, nil
+}
+
+// processAvatar 校验并转换头像为 avatarSize x avatarSize 的 PNG
+func processAvatar(data []byte) ([]byte, error) {
+	if !allowedAvatarTypes[http.DetectContentType(data)] {
+		return nil, domain.ErrInvalidAvatar
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, domain.ErrInvalidAvatar
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width > maxAvatarPixels || cfg.Height > maxAvatarPixels {
+		return nil, domain.ErrInvalidAvatar
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, domain.ErrInvalidAvatar
+	}
+
+	// 居中裁剪为正方形
+	b := src.Bounds()
+	side := min(b.Dx(), b.Dy())
+	x0 := b.Min.X + (b.Dx()-side)/2
+	y0 := b.Min.Y + (b.Dy()-side)/2
+	crop := image.Rect(x0, y0, x0+side, y0+side)
+
+	dst := image.NewRGBA(image.Rect(0, 0, avatarSize, avatarSize))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, crop, draw.Src, nil)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, dst); err != nil {
+		return nil, fmt.Errorf("encode avatar: %w", err)
+	}
+	return buf.Bytes(), nil
+}
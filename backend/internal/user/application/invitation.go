@@ -0,0 +1,142 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"mygo/internal/user/domain"
+)
+
+const (
+	defaultInvitationTTL = 7 * 24 * time.Hour
+	maxInvitationTTL     = 90 * 24 * time.Hour
+	maxInvitationUses    = 1000
+)
+
+// CreateInvitation 创建注册邀请
+func (s *AppService) CreateInvitation(ctx context.Context, creatorID int64, cmd domain.CreateInvitationCommand) (*domain.Invitation, string, error) {
+	if s.inviteRepo == nil {
+		return nil, "", errors.New("invitations are not enabled")
+	}
+	if creatorID == 0 || len(cmd.Note) > 255 {
+		return nil, "", domain.ErrInvalidInput
+	}
+	if cmd.MaxUses < 0 || cmd.MaxUses > maxInvitationUses {
+		return nil, "", domain.ErrInvalidInput
+	}
+	if cmd.ExpiresIn < 0 || cmd.ExpiresIn > maxInvitationTTL {
+		return nil, "", domain.ErrInvalidInput
+	}
+	if cmd.MaxUses == 0 {
+		cmd.MaxUses = 1
+	}
+	if cmd.ExpiresIn == 0 {
+		cmd.ExpiresIn = defaultInvitationTTL
+	}
+
+	code, err := generateInvitationCode()
+	if err != nil {
+		return nil, "", fmt.Errorf("generate invitation code: %w", err)
+	}
+
+	inv := &domain.Invitation{
+		Prefix:    code[:len(domain.InvitationPrefix)+6],
+		CodeHash:  hashToken(code),
+		CreatedBy: creatorID,
+		Note:      cmd.Note,
+		MaxUses:   cmd.MaxUses,
+		ExpiresAt: time.Now().Add(cmd.ExpiresIn),
+	}
+	if err := s.inviteRepo.Create(ctx, inv); err != nil {
+		return nil, "", fmt.Errorf("create invitation: %w", err)
+	}
+
+	s.recordEvent(ctx, domain.AuthEvent{
+		UserID: creatorID,
+		Type:   domain.EventInviteCreated,
+		Detail: fmt.Sprintf("invitation %d (%s) max_uses=%d", inv.ID, inv.Prefix, inv.MaxUses),
+	})
+	return inv, code, nil
+}
+
+// ListInvitations 分页列出邀请
+func (s *AppService) ListInvitations(ctx context.Context, limit, offset int) ([]*domain.Invitation, int64, error) {
+	if s.inviteRepo == nil {
+		return nil, 0, nil
+	}
+	return s.inviteRepo.List(ctx, limit, offset)
+}
+
+// RevokeInvitation 撤销邀请，已注册的用户不受影响
+func (s *AppService) RevokeInvitation(ctx context.Context, invitationID int64) error {
+	if s.inviteRepo == nil {
+		return domain.ErrInvitationNotFound
+	}
+	if invitationID == 0 {
+		return domain.ErrInvalidInput
+	}
+	if err := s.inviteRepo.Revoke(ctx, invitationID, time.Now()); err != nil {
+		return err
+	}
+
+	s.recordEvent(ctx, domain.AuthEvent{
+		Type:   domain.EventInviteRevoked,
+		Detail: fmt.Sprintf("invitation %d", invitationID),
+	})
+	return nil
+}
+
+// consumeInvitation 校验邀请码并占用一次使用次数。
+// 邀请码无效、过期、已撤销或用尽时统一返回 invite_code 字段错误，不区分原因。
+func (s *AppService) consumeInvitation(ctx context.Context, code string) (*domain.Invitation, error) {
+	if s.inviteRepo == nil {
+		return nil, errors.New("invitations are not enabled")
+	}
+
+	invalid := &domain.ValidationError{Fields: []domain.FieldError{{
+		Field:   "invite_code",
+		Code:    "invalid",
+		Message: "is invalid or expired",
+	}}}
+	if code == "" {
+		return nil, &domain.ValidationError{Fields: []domain.FieldError{{
+			Field:   "invite_code",
+			Code:    "required",
+			Message: "is required",
+		}}}
+	}
+
+	inv, err := s.inviteRepo.GetByHash(ctx, hashToken(code))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvitationNotFound) {
+			return nil, invalid
+		}
+		return nil, fmt.Errorf("get invitation: %w", err)
+	}
+
+	now := time.Now()
+	if !inv.IsUsable(now) {
+		return nil, invalid
+	}
+	// 并发注册时以仓储的条件更新为准
+	if err := s.inviteRepo.Consume(ctx, inv.ID, now); err != nil {
+		if errors.Is(err, domain.ErrInvalidInvitation) {
+			return nil, invalid
+		}
+		return nil, fmt.Errorf("consume invitation: %w", err)
+	}
+	return inv, nil
+}
+
+// generateInvitationCode 生成明文邀请码：固定前缀 + 16 字节随机数
+func generateInvitationCode() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return domain.InvitationPrefix + hex.EncodeToString(bytes), nil
+}
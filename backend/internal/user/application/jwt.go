@@ -0,0 +1,191 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"mygo/internal/logging"
+	"mygo/internal/user/domain"
+)
+
+// DefaultRefreshTokenTTL 刷新令牌默认有效期，每次刷新重新计算
+const DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+
+// IssueTokenPair 校验用户名密码后签发令牌对，开启一个新的刷新令牌家族
+func (s *AppService) IssueTokenPair(ctx context.Context, username, password string) (*domain.TokenPair, *domain.User, error) {
+	if s.accessIssuer == nil || s.refreshStore == nil {
+		return nil, nil, errors.New("jwt tokens are not enabled")
+	}
+	if username == "" || password == "" {
+		return nil, nil, domain.ErrInvalidInput
+	}
+
+	user, err := s.verifyCredentials(ctx, username, password)
+	if err != nil {
+		return nil, nil, err
+	}
+	// 需要重置密码的用户只能通过会话登录后修改密码
+	if user.MustChangePassword {
+		return nil, nil, domain.ErrPasswordChangeRequired
+	}
+
+	familyID, err := randomHex(16)
+	if err != nil {
+		return nil, nil, fmt.Errorf("generate token family: %w", err)
+	}
+	pair, err := s.issueTokenPair(ctx, user, familyID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.recordEvent(ctx, domain.AuthEvent{
+		UserID:   user.UserID,
+		Username: user.Username,
+		Type:     domain.EventLoginSucceeded,
+		Detail:   "jwt",
+	})
+	return pair, user, nil
+}
+
+// RefreshTokenPair 轮换刷新令牌：旧令牌标记为已使用，签发同一家族的新令牌对。
+// 已使用的令牌再次出现说明可能泄露，整个家族作废并记录审计事件。
+func (s *AppService) RefreshTokenPair(ctx context.Context, refreshToken string) (*domain.TokenPair, error) {
+	if s.accessIssuer == nil || s.refreshStore == nil {
+		return nil, errors.New("jwt tokens are not enabled")
+	}
+	if !strings.HasPrefix(refreshToken, domain.RefreshTokenPrefix) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	rt, err := s.refreshStore.Rotate(ctx, hashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, domain.ErrRefreshTokenReused) {
+			s.recordEvent(ctx, domain.AuthEvent{
+				UserID: rt.UserID,
+				Type:   domain.EventRefreshReused,
+				Detail: "token family " + rt.FamilyID + " revoked",
+			})
+		}
+		return nil, err
+	}
+
+	// 用户被注销、删除或停用后不再续期
+	user, err := s.userRepo.GetByUserID(ctx, rt.UserID)
+	if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	if user == nil || user.IsDisabled() || user.MustChangePassword {
+		if err := s.refreshStore.RevokeFamily(ctx, rt.FamilyID); err != nil {
+			logging.FromContext(ctx).Error("revoke refresh family failed", "family_id", rt.FamilyID, "error", err)
+		}
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	return s.issueTokenPair(ctx, user, rt.FamilyID)
+}
+
+// RevokeRefreshToken 作废刷新令牌所在的家族，令牌无效时视为成功
+func (s *AppService) RevokeRefreshToken(ctx context.Context, refreshToken string) error {
+	if s.refreshStore == nil || !strings.HasPrefix(refreshToken, domain.RefreshTokenPrefix) {
+		return nil
+	}
+
+	rt, err := s.refreshStore.Get(ctx, hashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidRefreshToken) {
+			return nil
+		}
+		return fmt.Errorf("get refresh token: %w", err)
+	}
+	if err := s.refreshStore.RevokeFamily(ctx, rt.FamilyID); err != nil {
+		return fmt.Errorf("revoke refresh family: %w", err)
+	}
+
+	s.recordEvent(ctx, domain.AuthEvent{UserID: rt.UserID, Type: domain.EventLogout, Detail: "jwt"})
+	return nil
+}
+
+// AuthenticateAccessToken 校验 JWT 访问令牌并解析访问主体。
+// 只验签不查库，角色变更在访问令牌过期后生效。
+func (s *AppService) AuthenticateAccessToken(ctx context.Context, token string) (*domain.Principal, error) {
+	if s.accessIssuer == nil {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	claims, err := s.accessIssuer.Verify(token)
+	if err != nil {
+		return nil, domain.ErrUnauthenticated
+	}
+	return &domain.Principal{
+		UserID:   claims.UserID,
+		Username: claims.Username,
+		Roles:    claims.Roles,
+	}, nil
+}
+
+// JWKS 返回验签公钥集合
+func (s *AppService) JWKS() ([]byte, error) {
+	if s.accessIssuer == nil {
+		return nil, errors.New("jwt tokens are not enabled")
+	}
+	return s.accessIssuer.JWKS()
+}
+
+// issueTokenPair 签发访问令牌与指定家族的新刷新令牌
+func (s *AppService) issueTokenPair(ctx context.Context, user *domain.User, familyID string) (*domain.TokenPair, error) {
+	claims := &domain.AccessClaims{
+		UserID:   user.UserID,
+		Username: user.Username,
+		Roles:    user.Roles,
+	}
+	access, err := s.accessIssuer.Issue(claims)
+	if err != nil {
+		return nil, fmt.Errorf("issue access token: %w", err)
+	}
+
+	secret, err := randomHex(32)
+	if err != nil {
+		return nil, fmt.Errorf("generate refresh token: %w", err)
+	}
+	refresh := domain.RefreshTokenPrefix + secret
+	refreshExpiresAt := time.Now().Add(s.refreshTTL)
+	if err := s.refreshStore.Save(ctx, hashToken(refresh), &domain.RefreshToken{
+		UserID:    user.UserID,
+		FamilyID:  familyID,
+		ExpiresAt: refreshExpiresAt,
+	}); err != nil {
+		return nil, fmt.Errorf("save refresh token: %w", err)
+	}
+
+	return &domain.TokenPair{
+		AccessToken:      access,
+		AccessExpiresAt:  claims.ExpiresAt,
+		RefreshToken:     refresh,
+		RefreshExpiresAt: refreshExpiresAt,
+	}, nil
+}
+
+// revokeRefreshTokens 作废用户全部刷新令牌（修改密码、注销账号时调用）
+func (s *AppService) revokeRefreshTokens(ctx context.Context, userID int64) error {
+	if s.refreshStore == nil {
+		return nil
+	}
+	if err := s.refreshStore.RevokeByUser(ctx, userID); err != nil {
+		return fmt.Errorf("revoke refresh tokens: %w", err)
+	}
+	return nil
+}
+
+// randomHex 生成 n 字节随机数的十六进制表示
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,140 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"mygo/internal/user/domain"
+)
+
+// GetProfile 获取当前用户资料
+func (s *AppService) GetProfile(ctx context.Context, userID int64) (*domain.User, error) {
+	if userID == 0 {
+		return nil, domain.ErrInvalidInput
+	}
+
+	user, err := s.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 清除敏感信息
+	user.Password = ""
+	return user, nil
+}
+
+// UpdateProfile 更新当前用户资料
+func (s *AppService) UpdateProfile(ctx context.Context, userID int64, cmd domain.UpdateProfileCommand) (*domain.User, error) {
+	if userID == 0 {
+		return nil, domain.ErrInvalidInput
+	}
+
+	user, err := s.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 与注册使用相同的字段校验，一次返回全部字段错误
+	var fields []domain.FieldError
+	changeUsername := cmd.Username != nil && *cmd.Username != user.Username
+	changeEmail := cmd.Email != nil && *cmd.Email != user.Email
+	if changeUsername {
+		// 只改变大小写等写法时规范形式不变，不受保留名限制
+		sameName := domain.CanonicalUsername(*cmd.Username) == domain.CanonicalUsername(user.Username)
+		fields = append(fields, s.checkUsername(*cmd.Username, sameName)...)
+	}
+	if changeEmail {
+		fields = append(fields, checkEmail(*cmd.Email)...)
+	}
+	if len(fields) > 0 {
+		return nil, &domain.ValidationError{Fields: fields}
+	}
+
+	if changeUsername {
+		if err := s.ensureAvailable(ctx, "username", s.userRepo.GetByUsername, *cmd.Username, user.UserID); err != nil {
+			return nil, err
+		}
+		user.Username = *cmd.Username
+	}
+
+	if changeEmail {
+		if err := s.ensureAvailable(ctx, "email", s.userRepo.GetByEmail, *cmd.Email, user.UserID); err != nil {
+			return nil, err
+		}
+		user.Email = *cmd.Email
+		// 新邮箱尚未验证
+		user.EmailVerifiedAt = nil
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("update user: %w", err)
+	}
+
+	user.Password = ""
+	return user, nil
+}
+
+// ChangePassword 修改密码
+func (s *AppService) ChangePassword(ctx context.Context, userID int64, cmd domain.ChangePasswordCommand) error {
+	if userID == 0 || cmd.CurrentPassword == "" || cmd.NewPassword == "" {
+		return domain.ErrInvalidInput
+	}
+
+	user, err := s.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	ok, _, err := s.verifyPassword(cmd.CurrentPassword, user.Password)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.ErrIncorrectPassword
+	}
+
+	if err := s.validateNewPassword(ctx, cmd.NewPassword, user.Username, user.Email); err != nil {
+		return err
+	}
+
+	hashedPassword, err := s.hashPassword(cmd.NewPassword)
+	if err != nil {
+		return err
+	}
+	user.Password = hashedPassword
+	user.MustChangePassword = false
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("update user: %w", err)
+	}
+
+	s.recordEvent(ctx, domain.AuthEvent{UserID: user.UserID, Username: user.Username, Type: domain.EventPasswordChanged})
+
+	// 撤销除当前会话外的全部会话
+	if s.sessionCache != nil {
+		if err := s.sessionCache.DeleteByUser(ctx, userID, cmd.KeepSessionID); err != nil {
+			return fmt.Errorf("revoke sessions: %w", err)
+		}
+		s.recordEvent(ctx, domain.AuthEvent{
+			UserID:   user.UserID,
+			Username: user.Username,
+			Type:     domain.EventSessionsRevoked,
+			Detail:   "other sessions revoked after password change",
+		})
+	}
+	return s.revokeRefreshTokens(ctx, userID)
+}
+
+// ensureAvailable 确认用户名/邮箱未被其他用户占用。
+// 查找按规范形式进行，当前用户只修改大小写时会查到自己，不视为冲突。
+func (s *AppService) ensureAvailable(ctx context.Context, field string, lookup func(context.Context, string) (*domain.User, error), value string, selfID int64) error {
+	existing, err := lookup(ctx, value)
+	if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+		return fmt.Errorf("check availability: %w", err)
+	}
+	if existing != nil && existing.UserID != selfID {
+		return &domain.ConflictError{Field: field}
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"mygo/internal/user/domain"
+)
+
+// ListRoles 获取用户角色
+func (s *AppService) ListRoles(ctx context.Context, userID int64) ([]domain.Role, error) {
+	if userID == 0 {
+		return nil, domain.ErrInvalidInput
+	}
+
+	user, err := s.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return user.Roles, nil
+}
+
+// GrantRole 为用户授予角色
+func (s *AppService) GrantRole(ctx context.Context, userID int64, role domain.Role) error {
+	if userID == 0 {
+		return domain.ErrInvalidInput
+	}
+	if _, err := domain.ParseRole(string(role)); err != nil {
+		return err
+	}
+
+	// 确认用户存在，避免外键错误被当成 500
+	user, err := s.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRepo.AddRole(ctx, userID, role); err != nil {
+		return fmt.Errorf("add role: %w", err)
+	}
+
+	s.recordEvent(ctx, domain.AuthEvent{UserID: userID, Username: user.Username, Type: domain.EventRoleGranted, Detail: string(role)})
+	return nil
+}
+
+// RevokeRole 撤销用户角色。不能撤销自己的 admin 角色，也不能撤销最后一个可登录管理员的 admin 角色，
+// 避免所有人被锁在管理接口之外
+func (s *AppService) RevokeRole(ctx context.Context, userID int64, role domain.Role) error {
+	if userID == 0 {
+		return domain.ErrInvalidInput
+	}
+	if _, err := domain.ParseRole(string(role)); err != nil {
+		return err
+	}
+	if role == domain.RoleAdmin && userID == domain.RequestMetaFrom(ctx).ActorID {
+		return domain.ErrRevokeOwnAdmin
+	}
+
+	user, err := s.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRepo.RemoveRole(ctx, userID, role); err != nil {
+		return err
+	}
+
+	s.recordEvent(ctx, domain.AuthEvent{UserID: userID, Username: user.Username, Type: domain.EventRoleRevoked, Detail: string(role)})
+	return nil
+}
+
+// SeedAdminCommand 启动时初始化管理员的参数
+type SeedAdminCommand struct {
+	Username string
+	Email    string
+	Password string
+}
+
+// SeedAdmin 确保指定用户拥有 admin 角色。
+// 用户不存在且提供了邮箱和密码时先注册；重复执行是幂等的。
+func (s *AppService) SeedAdmin(ctx context.Context, cmd SeedAdminCommand) (*domain.User, error) {
+	user, _, err := s.seedUser(ctx, SeedUserCommand{
+		Username: cmd.Username,
+		Email:    cmd.Email,
+		Password: cmd.Password,
+		Roles:    []domain.Role{domain.RoleAdmin},
+	}, "seeded at startup")
+	return user, err
+}
+
+// SeedUserCommand 通过种子数据创建用户的参数
+type SeedUserCommand struct {
+	Username string
+	Email    string
+	Password string
+	Roles    []domain.Role
+}
+
+// SeedUser 确保用户存在并拥有指定角色，返回用户以及是否为新建。
+// 用户已存在时不修改邮箱与密码，只补齐缺少的角色；重复执行是幂等的。
+func (s *AppService) SeedUser(ctx context.Context, cmd SeedUserCommand) (*domain.User, bool, error) {
+	return s.seedUser(ctx, cmd, "seeded from fixtures")
+}
+
+// seedUser 按需注册用户并授予角色，source 记录在角色授予事件中
+func (s *AppService) seedUser(ctx context.Context, cmd SeedUserCommand, source string) (*domain.User, bool, error) {
+	if cmd.Username == "" {
+		return nil, false, domain.ErrInvalidInput
+	}
+	for _, role := range cmd.Roles {
+		if _, err := domain.ParseRole(string(role)); err != nil {
+			return nil, false, err
+		}
+	}
+
+	created := false
+	user, err := s.userRepo.GetByUsername(ctx, cmd.Username)
+	if err != nil {
+		if !errors.Is(err, domain.ErrUserNotFound) {
+			return nil, false, fmt.Errorf("get user: %w", err)
+		}
+		if cmd.Email == "" || cmd.Password == "" {
+			return nil, false, fmt.Errorf("seed user %q: user not found and no credentials provided", cmd.Username)
+		}
+		// 种子用户不受注册模式限制，但仍需通过注册校验
+		// 管理员账号通常使用保留用户名（如 admin），这里不做保留名检查
+		if err := s.validateRegistration(ctx, cmd.Username, cmd.Email, cmd.Password, true); err != nil {
+			return nil, false, fmt.Errorf("register %q: %w", cmd.Username, err)
+		}
+		user, err = s.createUser(ctx, cmd.Username, cmd.Email, cmd.Password, 0)
+		if err != nil {
+			return nil, false, fmt.Errorf("register %q: %w", cmd.Username, err)
+		}
+		created = true
+	}
+
+	for _, role := range cmd.Roles {
+		if user.HasRole(role) {
+			continue
+		}
+		if err := s.userRepo.AddRole(ctx, user.UserID, role); err != nil {
+			return nil, false, fmt.Errorf("add %s role: %w", role, err)
+		}
+		s.recordEvent(ctx, domain.AuthEvent{
+			UserID:   user.UserID,
+			Username: user.Username,
+			Type:     domain.EventRoleGranted,
+			Detail:   fmt.Sprintf("%s (%s)", role, source),
+		})
+		user.Roles = append(user.Roles, role)
+	}
+	return user, created, nil
+}
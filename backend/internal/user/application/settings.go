@@ -0,0 +1,40 @@
+package application
+
+import (
+	"mygo/internal/user/domain"
+)
+
+// Settings 可在运行时替换的注册与密码策略，配置热更新时通过 UpdateSettings 整体替换
+type Settings struct {
+	RegistrationMode  domain.RegistrationMode
+	ReservedUsernames []string
+	PasswordPolicy    domain.PasswordPolicy
+}
+
+// policySnapshot Settings 的不可变快照，读取时无需加锁
+type policySnapshot struct {
+	registrationMode domain.RegistrationMode
+	reservedNames    domain.ReservedNames
+	passwordPolicy   domain.PasswordPolicy
+}
+
+// UpdateSettings 原子替换注册与密码策略，对之后开始的请求生效
+func (s *AppService) UpdateSettings(cfg Settings) {
+	s.policySnap.Store(&policySnapshot{
+		registrationMode: cfg.RegistrationMode,
+		reservedNames:    domain.NewReservedNames(cfg.ReservedUsernames),
+		passwordPolicy:   cfg.PasswordPolicy,
+	})
+}
+
+// policy 返回当前策略快照，同一请求内应只读取一次以保持一致
+func (s *AppService) policy() *policySnapshot {
+	return s.policySnap.Load()
+}
+
+// updatePolicy 复制当前快照、修改后替换，供构造阶段的 Option 使用
+func (s *AppService) updatePolicy(fn func(*policySnapshot)) {
+	next := *s.policySnap.Load()
+	fn(&next)
+	s.policySnap.Store(&next)
+}
@@ -0,0 +1,84 @@
+package application
+
+import (
+	"context"
+	"net/mail"
+	"unicode/utf8"
+
+	"mygo/internal/logging"
+	"mygo/internal/user/domain"
+)
+
+// validateRegistration 校验注册参数，返回字段级错误。
+// allowReserved 为 true 时跳过保留用户名检查，仅用于管理员与种子用户初始化。
+func (s *AppService) validateRegistration(ctx context.Context, username, email, password string, allowReserved bool) error {
+	var fields []domain.FieldError
+	fields = append(fields, s.checkUsername(username, allowReserved)...)
+	fields = append(fields, checkEmail(email)...)
+
+	if password == "" {
+		fields = append(fields, domain.FieldError{Field: "password", Code: "required", Message: "is required"})
+	} else {
+		fields = append(fields, s.checkPassword(ctx, password, username, email)...)
+	}
+
+	if len(fields) > 0 {
+		return &domain.ValidationError{Fields: fields}
+	}
+	return nil
+}
+
+// checkUsername 校验用户名长度与保留名
+func (s *AppService) checkUsername(username string, allowReserved bool) []domain.FieldError {
+	switch n := utf8.RuneCountInString(username); {
+	case n == 0:
+		return []domain.FieldError{{Field: "username", Code: "required", Message: "is required"}}
+	case n < 3 || n > 64:
+		return []domain.FieldError{{Field: "username", Code: "invalid_length", Message: "must be 3 to 64 characters"}}
+	case !allowReserved && s.policy().reservedNames.Contains(username):
+		return []domain.FieldError{{Field: "username", Code: "reserved", Message: "is reserved"}}
+	}
+	return nil
+}
+
+// checkEmail 校验邮箱格式与长度
+func checkEmail(email string) []domain.FieldError {
+	if email == "" {
+		return []domain.FieldError{{Field: "email", Code: "required", Message: "is required"}}
+	}
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email || len(email) > 128 {
+		return []domain.FieldError{{Field: "email", Code: "invalid_format", Message: "is not a valid email address"}}
+	}
+	return nil
+}
+
+// validateNewPassword 校验修改后的密码
+func (s *AppService) validateNewPassword(ctx context.Context, password, username, email string) error {
+	if fields := s.checkPassword(ctx, password, username, email); len(fields) > 0 {
+		return &domain.ValidationError{Fields: fields}
+	}
+	return nil
+}
+
+// checkPassword 应用密码策略并检查泄露列表。
+// 泄露检查的数据源不可用时放行，避免外部服务故障阻断注册。
+func (s *AppService) checkPassword(ctx context.Context, password, username, email string) []domain.FieldError {
+	fields := s.policy().passwordPolicy.Validate(password, username, email)
+	if len(fields) > 0 || s.breachChecker == nil {
+		return fields
+	}
+
+	breached, err := s.breachChecker.IsBreached(ctx, password)
+	if err != nil {
+		logging.FromContext(ctx).Warn("breached password check failed", "error", err)
+		return fields
+	}
+	if breached {
+		fields = append(fields, domain.FieldError{
+			Field:   "password",
+			Code:    "breached",
+			Message: "appears in a known data breach, choose a different password",
+		})
+	}
+	return fields
+}
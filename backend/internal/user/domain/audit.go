@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// AuthEventType 认证审计事件类型
+type AuthEventType string
+
+const (
+	EventLoginSucceeded  AuthEventType = "login.succeeded"
+	EventLoginFailed     AuthEventType = "login.failed"
+	EventLogout          AuthEventType = "logout"
+	EventPasswordChanged AuthEventType = "password.changed"
+	EventSessionsRevoked AuthEventType = "sessions.revoked"
+	EventTokenCreated    AuthEventType = "token.created"
+	EventTokenRevoked    AuthEventType = "token.revoked"
+	EventRoleGranted     AuthEventType = "role.granted"
+	EventRoleRevoked     AuthEventType = "role.revoked"
+	EventUserUnlocked    AuthEventType = "user.unlocked"
+	EventAccountDeleted  AuthEventType = "account.deleted"
+	EventAccountRestored AuthEventType = "account.restored"
+	EventUserRegistered  AuthEventType = "user.registered"
+	EventInviteCreated   AuthEventType = "invitation.created"
+	EventInviteRevoked   AuthEventType = "invitation.revoked"
+	EventRefreshReused   AuthEventType = "refresh_token.reused"
+	EventUserDisabled    AuthEventType = "user.disabled"
+	EventUserEnabled     AuthEventType = "user.enabled"
+	EventPasswordReset   AuthEventType = "password.reset_required"
+	EventEmailVerified   AuthEventType = "email.verified"
+)
+
+// AuthEvent 认证审计事件（只追加，不修改）
+type AuthEvent struct {
+	ID int64
+	// UserID 事件涉及的用户，登录失败且用户不存在或用户已被彻底清除时为 0
+	UserID   int64
+	Username string
+	// ActorID 执行操作的用户（如管理员），与 UserID 相同或为 0 表示本人
+	ActorID int64
+	Type    AuthEventType
+	Detail  string
+
+	IP        string
+	UserAgent string
+	RequestID string
+	CreatedAt time.Time
+}
+
+// AuthEventFilter 审计事件查询条件，零值字段不参与过滤
+type AuthEventFilter struct {
+	UserID int64
+	Types  []AuthEventType
+	IP     string
+	From   *time.Time
+	To     *time.Time
+
+	Limit  int
+	Offset int
+}
+
+// AuthEventRepository 审计事件仓储接口（领域层定义，基础设施层实现）
+type AuthEventRepository interface {
+	Append(ctx context.Context, event *AuthEvent) error
+	// List 按时间倒序查询，返回当前页与总数
+	List(ctx context.Context, filter AuthEventFilter) ([]*AuthEvent, int64, error)
+	// AnonymizeByUser 匿名化用户的全部审计记录：去掉用户 ID、用户名、IP 与 User-Agent，
+	// 保留事件类型与时间，仅在账号彻底清除时使用
+	AnonymizeByUser(ctx context.Context, userID int64) error
+}
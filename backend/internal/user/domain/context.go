@@ -0,0 +1,25 @@
+package domain
+
+import "context"
+
+// RequestMeta 请求来源信息，由接口层注入 context，供限流和审计使用
+type RequestMeta struct {
+	IP        string
+	UserAgent string
+	RequestID string
+	// ActorID 发起请求的已认证用户，匿名请求为 0
+	ActorID int64
+}
+
+type requestMetaKey struct{}
+
+// WithRequestMeta 将请求来源信息写入 context
+func WithRequestMeta(ctx context.Context, meta RequestMeta) context.Context {
+	return context.WithValue(ctx, requestMetaKey{}, meta)
+}
+
+// RequestMetaFrom 从 context 读取请求来源信息，不存在时返回零值
+func RequestMetaFrom(ctx context.Context) RequestMeta {
+	meta, _ := ctx.Value(requestMetaKey{}).(RequestMeta)
+	return meta
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// CanonicalUsername 返回用户名的规范形式：NFKC 规范化后转小写。
+// 唯一性与登录查找都基于规范形式，"Anon"、"anon"、全角 "ａｎｏｎ" 视为同一用户名；
+// 展示时仍使用用户注册时的原始写法。
+func CanonicalUsername(username string) string {
+	return canonicalize(username)
+}
+
+// CanonicalEmail 返回邮箱的规范形式：NFKC 规范化后整体转小写。
+// 本地部分按 RFC 5321 区分大小写，但实际邮件服务几乎都不区分；
+// 不做服务商相关的改写（如去掉 Gmail 的点号与 +tag）。
+func CanonicalEmail(email string) string {
+	return canonicalize(email)
+}
+
+func canonicalize(s string) string {
+	// 小写化可能产生新的组合序列，再规范化一次保证结果稳定
+	return norm.NFKC.String(strings.ToLower(norm.NFKC.String(strings.TrimSpace(s))))
+}
+
+// DefaultReservedUsernames 默认保留的用户名，防止冒充系统账号或占用路由关键字
+var DefaultReservedUsernames = []string{
+	"admin", "administrator", "root", "system", "sysadmin", "superuser",
+	"support", "help", "security", "abuse", "postmaster", "webmaster", "noreply", "no-reply",
+	"mygo", "official", "staff", "moderator", "owner",
+	"api", "www", "mail", "me", "null", "undefined", "anonymous",
+}
+
+// ReservedNames 保留用户名集合，按规范形式匹配
+type ReservedNames map[string]struct{}
+
+// NewReservedNames 由名称列表构造，忽略空白项
+func NewReservedNames(names []string) ReservedNames {
+	r := make(ReservedNames, len(names))
+	for _, n := range names {
+		if c := CanonicalUsername(n); c != "" {
+			r[c] = struct{}{}
+		}
+	}
+	return r
+}
+
+// Contains 判断用户名是否被保留
+func (r ReservedNames) Contains(username string) bool {
+	_, ok := r[CanonicalUsername(username)]
+	return ok
+}
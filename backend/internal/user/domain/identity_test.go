@@ -0,0 +1,65 @@
+package domain
+
+import "testing"
+
+func TestCanonicalUsername(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "already canonical", in: "anon", want: "anon"},
+		{name: "case folding", in: "AnOn", want: "anon"},
+		{name: "fullwidth", in: "ａｎｏｎ", want: "anon"},
+		{name: "fullwidth upper", in: "ＡＮＯＮ", want: "anon"},
+		{name: "fullwidth digits", in: "user１２３", want: "user123"},
+		{name: "ligature", in: "ﬁle", want: "file"},
+		{name: "composed and decomposed", in: "José", want: "josé"},
+		{name: "non-latin case folding", in: "ΣΟΦΙΑ", want: "σοφια"},
+		{name: "cjk unchanged", in: "迷子", want: "迷子"},
+		{name: "surrounding whitespace", in: "  anon\t\n", want: "anon"},
+		{name: "ideographic space trimmed", in: "　anon　", want: "anon"},
+		{name: "inner whitespace kept", in: "an on", want: "an on"},
+		{name: "empty", in: "   ", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanonicalUsername(tt.in); got != tt.want {
+				t.Errorf("CanonicalUsername(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanonicalEmail(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "already canonical", in: "anon@example.com", want: "anon@example.com"},
+		{name: "case folding", in: "Anon@Example.COM", want: "anon@example.com"},
+		{name: "fullwidth", in: "ａｎｏｎ＠ｅｘａｍｐｌｅ．ｃｏｍ", want: "anon@example.com"},
+		{name: "surrounding whitespace", in: " anon@example.com \n", want: "anon@example.com"},
+		// 不做服务商相关的改写
+		{name: "dots and tags kept", in: "A.non+Tag@Gmail.com", want: "a.non+tag@gmail.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanonicalEmail(tt.in); got != tt.want {
+				t.Errorf("CanonicalEmail(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanonicalIdempotent(t *testing.T) {
+	for _, in := range []string{"ＡＮＯＮ", "José", "ﬁle", "Jose\u0301", " ΣΟΦΙΑ ", "A.non+Tag@Gmail.com"} {
+		once := CanonicalUsername(in)
+		if twice := CanonicalUsername(once); twice != once {
+			t.Errorf("CanonicalUsername not idempotent for %q: %q then %q", in, once, twice)
+		}
+	}
+}
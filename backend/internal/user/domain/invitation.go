@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RegistrationMode 注册模式
+type RegistrationMode string
+
+const (
+	// RegistrationOpen 任何人都可以注册
+	RegistrationOpen RegistrationMode = "open"
+	// RegistrationInvite 需要有效的邀请码
+	RegistrationInvite RegistrationMode = "invite"
+	// RegistrationClosed 关闭注册，仅能通过管理员初始化创建账号
+	RegistrationClosed RegistrationMode = "closed"
+)
+
+// ParseRegistrationMode 解析注册模式，空字符串视为 open
+func ParseRegistrationMode(s string) (RegistrationMode, error) {
+	switch m := RegistrationMode(s); m {
+	case "":
+		return RegistrationOpen, nil
+	case RegistrationOpen, RegistrationInvite, RegistrationClosed:
+		return m, nil
+	default:
+		return "", fmt.Errorf("unknown registration mode %q", s)
+	}
+}
+
+// InvitationPrefix 邀请码固定前缀
+const InvitationPrefix = "mgi_"
+
+// Invitation 注册邀请。只保存邀请码哈希，明文仅在创建时返回一次。
+type Invitation struct {
+	ID        int64
+	Prefix    string // 明文前缀，用于识别邀请码
+	CodeHash  string // SHA-256 十六进制
+	CreatedBy int64  // 发出邀请的用户
+	Note      string
+	MaxUses   int
+	UsedCount int
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+// IsUsable 判断邀请在给定时间是否仍可用于注册
+func (i *Invitation) IsUsable(now time.Time) bool {
+	return i.RevokedAt == nil && now.Before(i.ExpiresAt) && i.UsedCount < i.MaxUses
+}
+
+// CreateInvitationCommand 创建邀请参数
+type CreateInvitationCommand struct {
+	Note      string
+	MaxUses   int
+	ExpiresIn time.Duration
+}
+
+// InvitationRepository 邀请仓储接口（领域层定义，基础设施层实现）
+type InvitationRepository interface {
+	Create(ctx context.Context, inv *Invitation) error
+	// GetByHash 按邀请码哈希查找，不存在时返回 ErrInvitationNotFound
+	GetByHash(ctx context.Context, codeHash string) (*Invitation, error)
+	// List 按创建时间倒序分页查询，返回当前页与总数
+	List(ctx context.Context, limit, offset int) ([]*Invitation, int64, error)
+	// Revoke 撤销邀请，不存在时返回 ErrInvitationNotFound
+	Revoke(ctx context.Context, id int64, at time.Time) error
+	// Consume 原子地占用一次使用次数，邀请已失效时返回 ErrInvalidInvitation
+	Consume(ctx context.Context, id int64, now time.Time) error
+	// Release 归还一次使用次数（注册失败时调用）
+	Release(ctx context.Context, id int64) error
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// RefreshTokenPrefix 刷新令牌固定前缀
+const RefreshTokenPrefix = "mgr_"
+
+// AccessClaims 访问令牌（JWT）携带的声明。
+// 其他服务只需通过 JWKS 验签即可信任这些声明，无需回调本服务。
+type AccessClaims struct {
+	ID        string // jti
+	UserID    int64  // sub
+	Username  string
+	Roles     []Role
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// AccessTokenIssuer 访问令牌签发与校验（领域层定义，基础设施层实现）
+type AccessTokenIssuer interface {
+	// Issue 签发访问令牌，ID、IssuedAt、ExpiresAt 由实现填充
+	Issue(claims *AccessClaims) (string, error)
+	// Verify 校验签名、有效期、签发方与受众，失败返回 ErrUnauthenticated
+	Verify(token string) (*AccessClaims, error)
+	// JWKS 返回公开的 JSON Web Key Set
+	JWKS() ([]byte, error)
+}
+
+// TokenPair 访问令牌与刷新令牌
+type TokenPair struct {
+	AccessToken      string
+	AccessExpiresAt  time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
+
+// RefreshToken 刷新令牌记录。每次刷新签发同一家族（FamilyID）的新令牌，
+// 旧令牌被再次使用时视为泄露，整个家族作废。
+type RefreshToken struct {
+	UserID    int64
+	FamilyID  string
+	ExpiresAt time.Time
+}
+
+// RefreshTokenStore 刷新令牌存储（与 SessionCache 并列，基础设施层实现）
+type RefreshTokenStore interface {
+	// Save 保存新签发的刷新令牌
+	Save(ctx context.Context, tokenHash string, token *RefreshToken) error
+	// Get 查询令牌记录（不改变状态），不存在或家族已作废返回 ErrInvalidRefreshToken
+	Get(ctx context.Context, tokenHash string) (*RefreshToken, error)
+	// Rotate 原子地将令牌标记为已使用并返回其记录。
+	// 令牌不存在、过期或家族已作废返回 ErrInvalidRefreshToken；
+	// 令牌已被使用过返回 ErrRefreshTokenReused，并作废整个家族。
+	Rotate(ctx context.Context, tokenHash string) (*RefreshToken, error)
+	// RevokeFamily 作废一个令牌家族
+	RevokeFamily(ctx context.Context, familyID string) error
+	// RevokeByUser 作废用户的全部令牌家族
+	RevokeByUser(ctx context.Context, userID int64) error
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 // User 用户领域模型
 type User struct {
 	ID       int64
@@ -8,4 +10,75 @@ type User struct {
 	Email    string
 	Password string
 	Avatar   string
+	Roles    []Role
+
+	// InvitedBy 通过邀请注册时为邀请人的 UserID，否则为 0
+	InvitedBy int64
+
+	// EmailVerifiedAt 邮箱验证时间，未验证为 nil
+	EmailVerifiedAt *time.Time
+	// DisabledAt 管理员停用时间，非空时禁止登录
+	DisabledAt *time.Time
+	// MustChangePassword 管理员要求重置密码，修改密码前会话只能访问有限接口
+	MustChangePassword bool
+
+	CreatedAt time.Time
+	// DeletedAt 注销时间，非空表示处于注销宽限期，PurgeAfter 之后被彻底清除
+	DeletedAt  *time.Time
+	PurgeAfter *time.Time
+}
+
+// IsDisabled 判断用户是否被停用
+func (u *User) IsDisabled() bool {
+	return u.DisabledAt != nil
+}
+
+// HasRole 判断用户是否拥有指定角色
+func (u *User) HasRole(role Role) bool {
+	for _, r := range u.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Principal 已认证的访问主体（由会话或访问令牌解析得到）
+type Principal struct {
+	UserID    int64
+	Username  string
+	Roles     []Role
+	SessionID string
+
+	// 通过个人访问令牌认证时设置，Scopes 进一步收窄角色权限
+	TokenID int64
+	Scopes  []Permission
+
+	// MustChangePassword 用户被要求重置密码
+	MustChangePassword bool
+}
+
+// HasPermission 判断主体是否拥有指定权限
+func (p *Principal) HasPermission(perm Permission) bool {
+	if p == nil {
+		return false
+	}
+	if p.TokenID != 0 && !containsPermission(p.Scopes, perm) {
+		return false
+	}
+	for _, r := range p.Roles {
+		if r.Grants(perm) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsPermission(perms []Permission, perm Permission) bool {
+	for _, p := range perms {
+		if p == perm {
+			return true
+		}
+	}
+	return false
 }
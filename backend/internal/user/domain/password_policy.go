@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PasswordPolicy 密码策略
+type PasswordPolicy struct {
+	MinLength int
+	MaxLength int
+	// MinCharClasses 至少包含的字符类别数（小写、大写、数字、符号）
+	MinCharClasses int
+	// DisallowUserInfo 禁止密码包含用户名或邮箱本地部分
+	DisallowUserInfo bool
+}
+
+// DefaultPasswordPolicy 默认密码策略
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:        8,
+		MaxLength:        128,
+		MinCharClasses:   2,
+		DisallowUserInfo: true,
+	}
+}
+
+// Validate 校验密码，返回不满足的规则（空表示通过）
+func (p PasswordPolicy) Validate(password, username, email string) []FieldError {
+	var errs []FieldError
+	add := func(code, msg string) {
+		errs = append(errs, FieldError{Field: "password", Code: code, Message: msg})
+	}
+
+	length := utf8.RuneCountInString(password)
+	if p.MinLength > 0 && length < p.MinLength {
+		add("too_short", fmt.Sprintf("must be at least %d characters", p.MinLength))
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		add("too_long", fmt.Sprintf("must be at most %d characters", p.MaxLength))
+	}
+
+	if p.MinCharClasses > 0 && charClasses(password) < p.MinCharClasses {
+		add("too_simple", fmt.Sprintf("must contain at least %d of: lowercase, uppercase, digits, symbols", p.MinCharClasses))
+	}
+
+	if p.DisallowUserInfo {
+		lower := strings.ToLower(password)
+		local, _, _ := strings.Cut(email, "@")
+		for _, info := range []string{username, local} {
+			// 过短的片段容易误伤，忽略
+			if utf8.RuneCountInString(info) >= 3 && strings.Contains(lower, strings.ToLower(info)) {
+				add("contains_user_info", "must not contain your username or email")
+				break
+			}
+		}
+	}
+
+	return errs
+}
+
+// charClasses 统计密码包含的字符类别数
+func charClasses(s string) int {
+	var lower, upper, digit, symbol bool
+	for _, r := range s {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+	n := 0
+	for _, ok := range []bool{lower, upper, digit, symbol} {
+		if ok {
+			n++
+		}
+	}
+	return n
+}
+
+// BreachedPasswordChecker 泄露密码检查接口（领域层定义，基础设施层实现）
+type BreachedPasswordChecker interface {
+	IsBreached(ctx context.Context, password string) (bool, error)
+}
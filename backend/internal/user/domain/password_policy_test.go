@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPasswordPolicyValidate(t *testing.T) {
+	def := DefaultPasswordPolicy()
+
+	tests := []struct {
+		name     string
+		policy   PasswordPolicy
+		password string
+		username string
+		email    string
+		// wantCodes 期望的 FieldError.Code，按规则顺序
+		wantCodes []string
+	}{
+		{name: "valid", policy: def, password: "Tr0ub4dor", username: "alice", email: "alice@example.com"},
+		{name: "too short", policy: def, password: "Ab1", username: "bob", email: "bob@example.com", wantCodes: []string{"too_short"}},
+		{name: "min length counts runes", policy: def, password: "密码密码密码密码", username: "bob", email: "b@example.com", wantCodes: []string{"too_simple"}},
+		{name: "too long", policy: def, password: "Aa1" + strings.Repeat("x", 126), username: "bob", email: "b@example.com", wantCodes: []string{"too_long"}},
+		{name: "single class", policy: def, password: "abcdefghij", username: "bob", email: "b@example.com", wantCodes: []string{"too_simple"}},
+		{name: "lower and digit", policy: def, password: "abcdefgh12", username: "bob", email: "b@example.com"},
+		{name: "symbols count as a class", policy: def, password: "abcdefgh!!", username: "bob", email: "b@example.com"},
+		{
+			name:      "three classes required",
+			policy:    PasswordPolicy{MinCharClasses: 3},
+			password:  "abcdefgh12",
+			wantCodes: []string{"too_simple"},
+		},
+		{name: "contains username", policy: def, password: "xxAlice123", username: "alice", email: "a@example.com", wantCodes: []string{"contains_user_info"}},
+		{name: "contains email local part", policy: def, password: "Carol.w2024", username: "bob", email: "carol.w@example.com", wantCodes: []string{"contains_user_info"}},
+		{name: "email domain allowed", policy: def, password: "Example2024", username: "bob", email: "b@example.com"},
+		{name: "short username ignored", policy: def, password: "Jo12345678", username: "jo", email: "jo@example.com"},
+		{
+			name:     "user info allowed when disabled",
+			policy:   PasswordPolicy{MinLength: 8, MinCharClasses: 2},
+			password: "alice12345",
+			username: "alice",
+			email:    "alice@example.com",
+		},
+		{
+			name:      "multiple violations",
+			policy:    def,
+			password:  "alice",
+			username:  "alice",
+			email:     "alice@example.com",
+			wantCodes: []string{"too_short", "too_simple", "contains_user_info"},
+		},
+		{name: "zero policy accepts anything", password: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.policy.Validate(tt.password, tt.username, tt.email)
+			var codes []string
+			for _, e := range errs {
+				if e.Field != "password" {
+					t.Errorf("Field = %q, want password", e.Field)
+				}
+				codes = append(codes, e.Code)
+			}
+			if !slices.Equal(codes, tt.wantCodes) {
+				t.Fatalf("codes = %v, want %v", codes, tt.wantCodes)
+			}
+		})
+	}
+}
@@ -1,15 +1,49 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // UserRepository 用户仓储接口（领域层定义，基础设施层实现）
 type UserRepository interface {
 	Create(ctx context.Context, user *User) error
 	GetByID(ctx context.Context, id int64) (*User, error)
+	GetByUserID(ctx context.Context, userID int64) (*User, error)
 	GetByUsername(ctx context.Context, username string) (*User, error)
 	GetByEmail(ctx context.Context, email string) (*User, error)
 	Update(ctx context.Context, user *User) error
-	Delete(ctx context.Context, id int64) error
+	// List 按条件分页查询，返回当前页与总数
+	List(ctx context.Context, filter UserFilter) ([]*User, int64, error)
+
+	// SoftDelete 标记用户为已注销，之后的查询不再返回该用户
+	SoftDelete(ctx context.Context, userID int64, purgeAfter time.Time) error
+	// Restore 撤销宽限期内的注销
+	Restore(ctx context.Context, userID int64) error
+	// ListPendingPurge 列出宽限期已过、待清除的已注销用户
+	ListPendingPurge(ctx context.Context, before time.Time, limit int) ([]*User, error)
+	// Purge 彻底删除用户及其角色、令牌（外键级联）
+	Purge(ctx context.Context, userID int64) error
+
+	// AddRole 为用户添加角色（已存在时不报错）
+	AddRole(ctx context.Context, userID int64, role Role) error
+	// RemoveRole 移除用户角色，未分配时返回 ErrRoleNotAssigned。
+	// 移除 admin 时需保证至少保留一个可登录（未停用、未注销）的管理员，否则返回 ErrLastAdmin
+	RemoveRole(ctx context.Context, userID int64, role Role) error
+}
+
+// UserFilter 用户查询条件，零值字段不参与过滤
+type UserFilter struct {
+	UsernamePrefix string
+	EmailPrefix    string
+	CreatedFrom    *time.Time
+	CreatedTo      *time.Time
+	// Verified 非空时按邮箱是否已验证过滤
+	Verified *bool
+	Role     Role
+
+	Limit  int
+	Offset int
 }
 
 // SessionData 会话数据
@@ -19,10 +53,62 @@ type SessionData struct {
 	CreatedAt int64 // Unix timestamp
 }
 
+// SessionSummary 会话概要，只包含会话 ID 前缀，用于展示
+type SessionSummary struct {
+	IDPrefix  string
+	CreatedAt time.Time
+}
+
 // SessionCache 会话缓存接口（领域层定义，基础设施层实现）
 type SessionCache interface {
 	Set(ctx context.Context, sessionID string, data *SessionData) error
 	Get(ctx context.Context, sessionID string) (*SessionData, error)
 	Delete(ctx context.Context, sessionID string) error
 	Refresh(ctx context.Context, sessionID string) error
+
+	// ListByUser 列出用户的有效会话（sessionID -> 会话数据）
+	ListByUser(ctx context.Context, userID int64) (map[string]*SessionData, error)
+	// DeleteByUser 删除用户的全部会话，exceptSessionID 非空时保留该会话
+	DeleteByUser(ctx context.Context, userID int64, exceptSessionID string) error
+}
+
+// UserDataProvider 其他模块持有的用户数据，用于个人数据导出和注销清理。
+// 由拥有数据的模块实现并在 bootstrap 中注册。
+type UserDataProvider interface {
+	// Name 导出文件名（不含扩展名），如 "picks"
+	Name() string
+	// Export 返回可 JSON 序列化的用户数据
+	Export(ctx context.Context, userID int64) (any, error)
+	// Purge 删除用户数据
+	Purge(ctx context.Context, userID int64) error
+}
+
+// FileStorage 文件存储接口（领域层定义，基础设施层实现）
+type FileStorage interface {
+	// Put 保存文件并返回可公开访问的 URL
+	Put(ctx context.Context, key string, data []byte, contentType string) (url string, err error)
+	// Delete 删除 Put 返回的 URL 对应的文件，非本存储的 URL 忽略
+	Delete(ctx context.Context, url string) error
+}
+
+// LoginLimiter 登录失败限流接口（领域层定义，基础设施层实现）
+type LoginLimiter interface {
+	// Check 返回用户名或 IP 的剩余锁定时间，0 表示未锁定
+	Check(ctx context.Context, username, ip string) (time.Duration, error)
+	// RecordFailure 记录一次失败，返回因此触发的锁定时间，0 表示未锁定
+	RecordFailure(ctx context.Context, username, ip string) (time.Duration, error)
+	// Reset 清除用户名的失败计数和锁定（登录成功或管理员解锁时调用）
+	Reset(ctx context.Context, username string) error
+}
+
+// IDGenerator 业务 ID 生成器接口（由共享的 idgen 包实现）
+type IDGenerator interface {
+	NextID() (int64, error)
+}
+
+// PasswordHasher 密码哈希接口（领域层定义，基础设施层实现）
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	// Verify 校验密码；needsRehash 表示哈希使用了过时的算法或参数，应在登录成功后重新哈希
+	Verify(password, encoded string) (ok bool, needsRehash bool, err error)
 }
@@ -1,11 +1,17 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"io"
+)
 
 // UserService 用户领域服务接口（用例层实现）
 type UserService interface {
-	// Register 用户注册
-	Register(ctx context.Context, username, email, password string) (*User, error)
+	// Register 用户注册，受注册模式限制
+	Register(ctx context.Context, cmd RegisterCommand) (*User, error)
+
+	// RegistrationMode 当前注册模式
+	RegistrationMode() RegistrationMode
 
 	// Login 用户登录，返回 sessionID
 	Login(ctx context.Context, username, password string) (sessionID string, user *User, err error)
@@ -15,4 +21,97 @@ type UserService interface {
 
 	// GetUserByID 根据 ID 获取用户（不含敏感信息）
 	GetUserByID(ctx context.Context, id int64) (*User, error)
+
+	// Authenticate 根据会话 ID 解析访问主体
+	Authenticate(ctx context.Context, sessionID string) (*Principal, error)
+
+	// ListRoles 获取用户角色
+	ListRoles(ctx context.Context, userID int64) ([]Role, error)
+
+	// GrantRole 为用户授予角色
+	GrantRole(ctx context.Context, userID int64, role Role) error
+
+	// RevokeRole 撤销用户角色
+	RevokeRole(ctx context.Context, userID int64, role Role) error
+
+	// AuthenticateToken 根据个人访问令牌解析访问主体
+	AuthenticateToken(ctx context.Context, token string) (*Principal, error)
+
+	// CreateToken 创建个人访问令牌，明文令牌仅此处返回
+	CreateToken(ctx context.Context, userID int64, cmd CreateTokenCommand) (token *PersonalAccessToken, plaintext string, err error)
+
+	// ListTokens 列出用户的个人访问令牌
+	ListTokens(ctx context.Context, userID int64) ([]*PersonalAccessToken, error)
+
+	// RevokeToken 撤销个人访问令牌
+	RevokeToken(ctx context.Context, userID, tokenID int64) error
+
+	// UnlockUser 解除用户的登录锁定
+	UnlockUser(ctx context.Context, userID int64) error
+
+	// GetProfile 获取当前用户资料（不含敏感信息）
+	GetProfile(ctx context.Context, userID int64) (*User, error)
+
+	// UpdateProfile 更新当前用户资料
+	UpdateProfile(ctx context.Context, userID int64, cmd UpdateProfileCommand) (*User, error)
+
+	// ChangePassword 校验当前密码后修改密码，并撤销其他会话
+	ChangePassword(ctx context.Context, userID int64, cmd ChangePasswordCommand) error
+
+	// UploadAvatar 上传并处理头像，更新 User.Avatar
+	UploadAvatar(ctx context.Context, userID int64, r io.Reader) (*User, error)
+
+	// ListAuthEvents 查询认证审计事件
+	ListAuthEvents(ctx context.Context, filter AuthEventFilter) ([]*AuthEvent, int64, error)
+
+	// DeleteAccount 校验密码后注销账号，宽限期结束后由后台任务彻底清除
+	DeleteAccount(ctx context.Context, userID int64, password string) (*User, error)
+
+	// RestoreAccount 撤销宽限期内的注销（管理员）
+	RestoreAccount(ctx context.Context, userID int64) error
+
+	// ExportUserData 导出用户个人数据（ZIP）
+	ExportUserData(ctx context.Context, userID int64) ([]byte, error)
+
+	// CreateInvitation 创建注册邀请，明文邀请码仅此处返回
+	CreateInvitation(ctx context.Context, creatorID int64, cmd CreateInvitationCommand) (inv *Invitation, code string, err error)
+
+	// ListInvitations 分页列出邀请
+	ListInvitations(ctx context.Context, limit, offset int) ([]*Invitation, int64, error)
+
+	// RevokeInvitation 撤销邀请
+	RevokeInvitation(ctx context.Context, invitationID int64) error
+
+	// IssueTokenPair 校验用户名密码后签发访问令牌与刷新令牌
+	IssueTokenPair(ctx context.Context, username, password string) (*TokenPair, *User, error)
+
+	// RefreshTokenPair 使用刷新令牌换取新的令牌对（旧刷新令牌随即失效）
+	RefreshTokenPair(ctx context.Context, refreshToken string) (*TokenPair, error)
+
+	// RevokeRefreshToken 作废刷新令牌所在的令牌家族
+	RevokeRefreshToken(ctx context.Context, refreshToken string) error
+
+	// AuthenticateAccessToken 根据 JWT 访问令牌解析访问主体（不访问存储）
+	AuthenticateAccessToken(ctx context.Context, token string) (*Principal, error)
+
+	// JWKS 返回验签公钥集合
+	JWKS() ([]byte, error)
+
+	// ListUsers 按条件分页查询用户（管理员）
+	ListUsers(ctx context.Context, filter UserFilter) ([]*User, int64, error)
+
+	// GetUserDetail 查看用户及其当前会话（管理员）
+	GetUserDetail(ctx context.Context, userID int64) (*User, []SessionSummary, error)
+
+	// DisableUser 停用用户：禁止登录并清除全部会话（管理员）
+	DisableUser(ctx context.Context, userID int64, reason string) error
+
+	// EnableUser 重新启用用户（管理员）
+	EnableUser(ctx context.Context, userID int64) error
+
+	// ForcePasswordReset 要求用户下次登录后修改密码，并清除全部会话（管理员）
+	ForcePasswordReset(ctx context.Context, userID int64) error
+
+	// MarkEmailVerified 将用户当前邮箱标记为已验证（管理员）
+	MarkEmailVerified(ctx context.Context, userID int64) error
 }
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// TokenPrefix 个人访问令牌的固定前缀，便于在日志和密钥扫描中识别
+const TokenPrefix = "mgp_"
+
+// PersonalAccessToken 个人访问令牌，供脚本通过 Authorization: Bearer 访问。
+// 只保存令牌哈希，明文仅在创建时返回一次。
+type PersonalAccessToken struct {
+	ID         int64
+	UserID     int64
+	Name       string
+	Prefix     string // 明文前缀，用于识别令牌
+	TokenHash  string // SHA-256 十六进制
+	Scopes     []Permission
+	ExpiresAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+// IsActive 判断令牌在给定时间是否可用
+func (t *PersonalAccessToken) IsActive(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// CreateTokenCommand 创建令牌参数
+type CreateTokenCommand struct {
+	Name      string
+	Scopes    []Permission
+	ExpiresIn time.Duration
+}
+
+// TokenRepository 个人访问令牌仓储接口
+type TokenRepository interface {
+	Create(ctx context.Context, token *PersonalAccessToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*PersonalAccessToken, error)
+	ListByUser(ctx context.Context, userID int64) ([]*PersonalAccessToken, error)
+	// Revoke 撤销令牌，令牌不存在或不属于该用户时返回 ErrTokenNotFound
+	Revoke(ctx context.Context, userID, tokenID int64, at time.Time) error
+	TouchLastUsed(ctx context.Context, tokenID int64, at time.Time) error
+	// RevokeAllByUser 撤销用户的全部有效令牌
+	RevokeAllByUser(ctx context.Context, userID int64, at time.Time) error
+}
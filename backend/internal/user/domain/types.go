@@ -1,11 +1,172 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
 
 // 领域错误定义
 var (
-	ErrUserNotFound       = errors.New("user not found")
-	ErrUserAlreadyExists  = errors.New("user already exists")
-	ErrInvalidCredentials = errors.New("invalid credentials")
-	ErrInvalidInput       = errors.New("invalid input")
+	ErrUserNotFound           = errors.New("user not found")
+	ErrUserAlreadyExists      = errors.New("user already exists")
+	ErrInvalidCredentials     = errors.New("invalid credentials")
+	ErrInvalidInput           = errors.New("invalid input")
+	ErrSessionNotFound        = errors.New("session not found")
+	ErrUnauthenticated        = errors.New("unauthenticated")
+	ErrForbidden              = errors.New("forbidden")
+	ErrInvalidRole            = errors.New("invalid role")
+	ErrRoleNotAssigned        = errors.New("role not assigned")
+	ErrTokenNotFound          = errors.New("token not found")
+	ErrInvalidScope           = errors.New("invalid scope")
+	ErrTooManyAttempts        = errors.New("too many failed login attempts")
+	ErrIncorrectPassword      = errors.New("incorrect password")
+	ErrInvalidAvatar          = errors.New("invalid avatar image")
+	ErrAvatarTooLarge         = errors.New("avatar image too large")
+	ErrValidation             = errors.New("validation failed")
+	ErrUserNotDeleted         = errors.New("user is not pending deletion")
+	ErrRegistrationClosed     = errors.New("registration is closed")
+	ErrInvitationNotFound     = errors.New("invitation not found")
+	ErrInvalidInvitation      = errors.New("invitation is invalid or expired")
+	ErrInvalidRefreshToken    = errors.New("invalid refresh token")
+	ErrRefreshTokenReused     = errors.New("refresh token reused")
+	ErrUserDisabled           = errors.New("user is disabled")
+	ErrPasswordChangeRequired = errors.New("password change required")
+	ErrLastAdmin              = errors.New("cannot revoke the last admin")
+	ErrRevokeOwnAdmin         = errors.New("cannot revoke your own admin role")
+)
+
+// FieldError 字段级校验错误
+type FieldError struct {
+	Field   string
+	Code    string
+	Message string
+}
+
+// ValidationError 字段级校验失败，errors.Is(err, ErrValidation) 为 true
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		msgs = append(msgs, f.Field+": "+f.Message)
+	}
+	return ErrValidation.Error() + ": " + strings.Join(msgs, "; ")
+}
+
+func (e *ValidationError) Unwrap() error { return ErrValidation }
+
+// ConflictError 用户名或邮箱已被占用，errors.Is(err, ErrUserAlreadyExists) 为 true。
+// Field 为 username 或 email，无法确定时为空。
+type ConflictError struct {
+	Field string
+}
+
+func (e *ConflictError) Error() string {
+	if e.Field == "" {
+		return ErrUserAlreadyExists.Error()
+	}
+	return e.Field + " already taken"
+}
+
+func (e *ConflictError) Unwrap() error { return ErrUserAlreadyExists }
+
+// UpdateProfileCommand 更新资料参数，nil 字段表示不修改
+type UpdateProfileCommand struct {
+	Username *string
+	Email    *string
+}
+
+// RegisterCommand 注册参数
+type RegisterCommand struct {
+	Username string
+	Email    string
+	Password string
+	// InviteCode 邀请码，仅 invite 注册模式下需要
+	InviteCode string
+}
+
+// ChangePasswordCommand 修改密码参数
+type ChangePasswordCommand struct {
+	CurrentPassword string
+	NewPassword     string
+	// KeepSessionID 修改后保留的当前会话，其余会话全部撤销
+	KeepSessionID string
+}
+
+// LockoutError 登录被临时锁定，errors.Is(err, ErrTooManyAttempts) 为 true
+type LockoutError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockoutError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", ErrTooManyAttempts, e.RetryAfter)
+}
+
+func (e *LockoutError) Unwrap() error { return ErrTooManyAttempts }
+
+// Role 用户角色
+type Role string
+
+const (
+	RoleAdmin  Role = "admin"
+	RoleEditor Role = "editor"
+)
+
+// Permission 权限标识，格式为 "<资源>:<动作>"
+type Permission string
+
+const (
+	PermAll        Permission = "*"
+	PermPickWrite  Permission = "pick:write"
+	PermUserManage Permission = "user:manage"
+	PermAuditRead  Permission = "audit:read"
+	// PermSystemRead 查看系统状态（健康检查详情），可授予监控用的个人访问令牌
+	PermSystemRead Permission = "system:read"
 )
+
+// rolePermissions 角色到权限的映射
+var rolePermissions = map[Role][]Permission{
+	RoleAdmin:  {PermAll},
+	RoleEditor: {PermPickWrite},
+}
+
+// knownPermissions 可作为令牌 scope 的权限
+var knownPermissions = []Permission{PermPickWrite, PermUserManage, PermAuditRead, PermSystemRead}
+
+// ParsePermission 解析并校验权限标识（不允许通配符）
+func ParsePermission(s string) (Permission, error) {
+	for _, p := range knownPermissions {
+		if string(p) == s {
+			return p, nil
+		}
+	}
+	return "", ErrInvalidScope
+}
+
+// ParseRole 解析并校验角色名
+func ParseRole(s string) (Role, error) {
+	role := Role(s)
+	if _, ok := rolePermissions[role]; !ok {
+		return "", ErrInvalidRole
+	}
+	return role, nil
+}
+
+// Permissions 返回角色拥有的权限
+func (r Role) Permissions() []Permission {
+	return rolePermissions[r]
+}
+
+// Grants 判断角色是否授予指定权限
+func (r Role) Grants(perm Permission) bool {
+	for _, p := range rolePermissions[r] {
+		if p == PermAll || p == perm {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package breach
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"mygo/internal/user/domain"
+)
+
+// prefixLength k-anonymity 查询时只暴露 SHA-1 的前 5 位
+const prefixLength = 5
+
+// RangeSource 按 SHA-1 前缀返回已泄露哈希的后缀列表（大写十六进制）
+type RangeSource interface {
+	Range(ctx context.Context, prefix string) ([]string, error)
+}
+
+// Checker 基于 k-anonymity 的泄露密码检查：只把哈希前缀交给数据源，
+// 在本地比对后缀，明文和完整哈希都不会离开进程。
+type Checker struct {
+	source RangeSource
+}
+
+// NewChecker 构造函数
+func NewChecker(source RangeSource) *Checker {
+	return &Checker{source: source}
+}
+
+// IsBreached 判断密码是否出现在泄露列表中
+func (c *Checker) IsBreached(ctx context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:prefixLength], hash[prefixLength:]
+
+	suffixes, err := c.source.Range(ctx, prefix)
+	if err != nil {
+		return false, fmt.Errorf("breach: range %s: %w", prefix, err)
+	}
+	for _, s := range suffixes {
+		if s == suffix {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// 确保 Checker 实现了 domain.BreachedPasswordChecker 接口
+var _ domain.BreachedPasswordChecker = (*Checker)(nil)
@@ -0,0 +1,63 @@
+package breach
+
+import (
+	"bufio"
+	"context"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+//go:embed data/common_sha1.txt
+var bundledList string
+
+// OfflineSource 内存中的哈希前缀索引，数据来自内置列表或本地文件
+type OfflineSource struct {
+	byPrefix map[string][]string
+}
+
+// NewBundledSource 使用内置的常见弱密码列表
+func NewBundledSource() (*OfflineSource, error) {
+	return parseList(strings.NewReader(bundledList))
+}
+
+// NewFileSource 从本地文件加载列表，每行一个完整 SHA-1，
+// 兼容 Pwned Passwords 导出的 "HASH:COUNT" 格式
+func NewFileSource(path string) (*OfflineSource, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("breach: open list: %w", err)
+	}
+	defer f.Close()
+	return parseList(f)
+}
+
+func parseList(r io.Reader) (*OfflineSource, error) {
+	src := &OfflineSource{byPrefix: make(map[string][]string)}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		hash, _, _ := strings.Cut(line, ":")
+		hash = strings.ToUpper(hash)
+		if len(hash) != 40 {
+			return nil, fmt.Errorf("breach: invalid sha1 %q", line)
+		}
+		prefix := hash[:prefixLength]
+		src.byPrefix[prefix] = append(src.byPrefix[prefix], hash[prefixLength:])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("breach: read list: %w", err)
+	}
+	return src, nil
+}
+
+// Range 返回前缀对应的后缀列表
+func (s *OfflineSource) Range(_ context.Context, prefix string) ([]string, error) {
+	return s.byPrefix[prefix], nil
+}
@@ -0,0 +1,76 @@
+package breach
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"mygo/internal/requestid"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// DefaultRangeAPIURL Pwned Passwords range API
+const DefaultRangeAPIURL = "https://api.pwnedpasswords.com/range/"
+
+// RangeAPISource 通过 HTTP range API 查询（Pwned Passwords 协议）
+type RangeAPISource struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewRangeAPISource 构造函数，baseURL 为空时使用 Pwned Passwords
+func NewRangeAPISource(baseURL string, client *http.Client) *RangeAPISource {
+	if baseURL == "" {
+		baseURL = DefaultRangeAPIURL
+	}
+	if client == nil {
+		client = &http.Client{
+			Timeout: 3 * time.Second,
+			// 传递 X-Request-ID 与 W3C traceparent，并为出站请求创建 client span
+			Transport: requestid.NewTransport(otelhttp.NewTransport(nil)),
+		}
+	}
+	return &RangeAPISource{
+		baseURL: strings.TrimRight(baseURL, "/") + "/",
+		client:  client,
+	}
+}
+
+// Range 查询前缀对应的后缀列表，响应每行格式为 "SUFFIX:COUNT"
+func (s *RangeAPISource) Range(ctx context.Context, prefix string) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+prefix, nil)
+	if err != nil {
+		return nil, err
+	}
+	// 填充响应，防止通过响应长度推断前缀
+	req.Header.Set("Add-Padding", "true")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var suffixes []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		suffix, count, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		// 填充行的计数为 0
+		if !ok || count == "0" {
+			continue
+		}
+		suffixes = append(suffixes, strings.ToUpper(suffix))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return suffixes, nil
+}
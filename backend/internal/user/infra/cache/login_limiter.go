@@ -0,0 +1,150 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"mygo/internal/infra"
+	"mygo/internal/user/domain"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	loginFailKeyPrefix = "login:fail:"
+	loginLockKeyPrefix = "login:lock:"
+)
+
+// LoginLimiterConfig 登录限流配置
+type LoginLimiterConfig struct {
+	// 同一用户名 / IP 在窗口期内允许的失败次数，超过后开始锁定
+	UserMaxFailures int
+	IPMaxFailures   int
+	// 首次锁定时长，之后每次失败翻倍，不超过 MaxLockout
+	BaseLockout time.Duration
+	MaxLockout  time.Duration
+	// 失败计数的保留窗口
+	Window time.Duration
+}
+
+// DefaultLoginLimiterConfig 返回默认配置
+func DefaultLoginLimiterConfig() LoginLimiterConfig {
+	return LoginLimiterConfig{
+		UserMaxFailures: 5,
+		IPMaxFailures:   20,
+		BaseLockout:     time.Minute,
+		MaxLockout:      time.Hour,
+		Window:          24 * time.Hour,
+	}
+}
+
+// LoginLimiter 基于 Redis 的登录失败计数与指数退避锁定
+type LoginLimiter struct {
+	redis *infra.RedisClient
+	// cfg 支持运行时替换阈值，见 SetMaxFailures
+	cfg atomic.Pointer[LoginLimiterConfig]
+}
+
+// NewLoginLimiter 构造函数
+func NewLoginLimiter(res *infra.Resources, cfg LoginLimiterConfig) (*LoginLimiter, error) {
+	if res == nil {
+		return nil, errors.New("login limiter: resources is nil")
+	}
+	if res.Redis == nil {
+		return nil, errors.New("login limiter: redis is nil")
+	}
+	l := &LoginLimiter{redis: res.Redis}
+	l.cfg.Store(&cfg)
+	return l, nil
+}
+
+// SetMaxFailures 替换用户名与 IP 的失败次数阈值，已有的计数与锁定不受影响
+func (l *LoginLimiter) SetMaxFailures(user, ip int) {
+	next := *l.cfg.Load()
+	next.UserMaxFailures = user
+	next.IPMaxFailures = ip
+	l.cfg.Store(&next)
+}
+
+// Check 返回剩余锁定时间
+func (l *LoginLimiter) Check(ctx context.Context, username, ip string) (time.Duration, error) {
+	pipe := l.redis.Pipeline()
+	userTTL := pipe.PTTL(ctx, lockKey("user", username))
+	ipTTL := pipe.PTTL(ctx, lockKey("ip", ip))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("login limiter: check: %w", err)
+	}
+
+	// key 不存在时 PTTL 返回负数
+	return max(userTTL.Val(), ipTTL.Val(), 0), nil
+}
+
+// RecordFailure 记录一次失败
+func (l *LoginLimiter) RecordFailure(ctx context.Context, username, ip string) (time.Duration, error) {
+	cfg := l.cfg.Load()
+	counters := []struct {
+		kind, id  string
+		threshold int
+	}{
+		{"user", username, cfg.UserMaxFailures},
+		{"ip", ip, cfg.IPMaxFailures},
+	}
+	if ip == "" {
+		counters = counters[:1]
+	}
+
+	pipe := l.redis.TxPipeline()
+	cmds := make([]*redis.IntCmd, len(counters))
+	for i, ct := range counters {
+		cmds[i] = pipe.Incr(ctx, failKey(ct.kind, ct.id))
+		pipe.Expire(ctx, failKey(ct.kind, ct.id), cfg.Window)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("login limiter: record failure: %w", err)
+	}
+
+	var lockout time.Duration
+	pipe = l.redis.TxPipeline()
+	for i, ct := range counters {
+		if d := lockoutFor(cfg, cmds[i].Val(), ct.threshold); d > 0 {
+			pipe.Set(ctx, lockKey(ct.kind, ct.id), 1, d)
+			lockout = max(lockout, d)
+		}
+	}
+	if lockout == 0 {
+		return 0, nil
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("login limiter: lock: %w", err)
+	}
+	return lockout, nil
+}
+
+// Reset 清除用户名的失败计数和锁定
+func (l *LoginLimiter) Reset(ctx context.Context, username string) error {
+	if err := l.redis.Del(ctx, failKey("user", username), lockKey("user", username)).Err(); err != nil {
+		return fmt.Errorf("login limiter: reset: %w", err)
+	}
+	return nil
+}
+
+// lockoutFor 根据失败次数计算锁定时长：超过阈值后从 BaseLockout 开始翻倍
+func lockoutFor(cfg *LoginLimiterConfig, failures int64, threshold int) time.Duration {
+	if threshold <= 0 || failures < int64(threshold) {
+		return 0
+	}
+	lockout := cfg.BaseLockout
+	for i := int64(threshold); i < failures && lockout < cfg.MaxLockout; i++ {
+		lockout *= 2
+	}
+	return min(lockout, cfg.MaxLockout)
+}
+
+func failKey(kind, id string) string { return loginFailKeyPrefix + kind + ":" + id }
+func lockKey(kind, id string) string { return loginLockKeyPrefix + kind + ":" + id }
+
+// 确保 LoginLimiter 实现了 domain.LoginLimiter 接口
+var _ domain.LoginLimiter = (*LoginLimiter)(nil)
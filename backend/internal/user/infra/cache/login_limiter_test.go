@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLockoutFor(t *testing.T) {
+	cfg := &LoginLimiterConfig{BaseLockout: time.Minute, MaxLockout: 10 * time.Minute}
+
+	tests := []struct {
+		name      string
+		failures  int64
+		threshold int
+		want      time.Duration
+	}{
+		{name: "below threshold", failures: 4, threshold: 5, want: 0},
+		{name: "at threshold", failures: 5, threshold: 5, want: time.Minute},
+		{name: "one over threshold doubles", failures: 6, threshold: 5, want: 2 * time.Minute},
+		{name: "two over threshold", failures: 7, threshold: 5, want: 4 * time.Minute},
+		{name: "three over threshold", failures: 8, threshold: 5, want: 8 * time.Minute},
+		{name: "capped at max", failures: 9, threshold: 5, want: 10 * time.Minute},
+		{name: "far over threshold stays capped", failures: 1000, threshold: 5, want: 10 * time.Minute},
+		{name: "threshold of one", failures: 1, threshold: 1, want: time.Minute},
+		{name: "zero threshold disables", failures: 100, threshold: 0, want: 0},
+		{name: "negative threshold disables", failures: 100, threshold: -1, want: 0},
+		{name: "no failures", failures: 0, threshold: 5, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lockoutFor(cfg, tt.failures, tt.threshold); got != tt.want {
+				t.Errorf("lockoutFor(%d, %d) = %s, want %s", tt.failures, tt.threshold, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLockoutForBaseAboveMax(t *testing.T) {
+	cfg := &LoginLimiterConfig{BaseLockout: time.Hour, MaxLockout: time.Minute}
+	if got := lockoutFor(cfg, 5, 5); got != time.Minute {
+		t.Errorf("lockoutFor = %s, want %s", got, time.Minute)
+	}
+}
@@ -0,0 +1,209 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"mygo/internal/infra"
+	"mygo/internal/user/domain"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	refreshKeyPrefix          = "refresh:"
+	refreshFamilyKeyPrefix    = "refresh_family:"
+	userRefreshFamiliesPrefix = "user_refresh_families:"
+)
+
+// rotateScript 原子地校验并标记刷新令牌为已使用。
+// 返回 {0} 无效；{-1, user_id, family_id} 重复使用（家族已作废）；
+// {1, user_id, family_id, expires_at} 成功。
+var rotateScript = redis.NewScript(`
+local f = redis.call("HMGET", KEYS[1], "user_id", "family_id", "used", "expires_at")
+if not f[1] then
+	return {0}
+end
+local familyKey = ARGV[1] .. f[2]
+if redis.call("EXISTS", familyKey) == 0 then
+	return {0}
+end
+if f[3] == "1" then
+	redis.call("DEL", familyKey)
+	return {-1, f[1], f[2]}
+end
+redis.call("HSET", KEYS[1], "used", "1")
+return {1, f[1], f[2], f[4]}
+`)
+
+// RefreshTokenStore 刷新令牌存储实现。
+//
+//	refresh:<hash>                  哈希：user_id、family_id、used、expires_at，TTL 至令牌过期
+//	refresh_family:<family_id>      存在即家族有效，删除即作废家族内全部令牌
+//	user_refresh_families:<user_id> 集合：用户的令牌家族索引
+//
+// 已使用的令牌保留到过期，用于识别重复使用。
+type RefreshTokenStore struct {
+	redis *infra.RedisClient
+}
+
+// NewRefreshTokenStore 构造函数
+func NewRefreshTokenStore(res *infra.Resources) (*RefreshTokenStore, error) {
+	if res == nil {
+		return nil, errors.New("refresh store: resources is nil")
+	}
+	if res.Redis == nil {
+		return nil, errors.New("refresh store: redis is nil")
+	}
+	return &RefreshTokenStore{redis: res.Redis}, nil
+}
+
+// Save 保存新签发的刷新令牌，并延长家族与用户索引的过期时间
+func (s *RefreshTokenStore) Save(ctx context.Context, tokenHash string, token *domain.RefreshToken) error {
+	if s.redis == nil {
+		return errors.New("refresh store: redis is nil")
+	}
+
+	ttl := time.Until(token.ExpiresAt)
+	if ttl <= 0 {
+		return errors.New("refresh store: token already expired")
+	}
+
+	key := refreshKeyPrefix + tokenHash
+	familyKey := refreshFamilyKeyPrefix + token.FamilyID
+	indexKey := userRefreshFamiliesKey(token.UserID)
+
+	pipe := s.redis.TxPipeline()
+	pipe.HSet(ctx, key,
+		"user_id", token.UserID,
+		"family_id", token.FamilyID,
+		"used", 0,
+		"expires_at", token.ExpiresAt.Unix(),
+	)
+	pipe.Expire(ctx, key, ttl)
+	pipe.Set(ctx, familyKey, token.UserID, ttl)
+	pipe.SAdd(ctx, indexKey, token.FamilyID)
+	pipe.Expire(ctx, indexKey, ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Get 查询令牌记录
+func (s *RefreshTokenStore) Get(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	if s.redis == nil {
+		return nil, errors.New("refresh store: redis is nil")
+	}
+
+	vals, err := s.redis.HMGet(ctx, refreshKeyPrefix+tokenHash, "user_id", "family_id", "expires_at").Result()
+	if err != nil {
+		return nil, err
+	}
+	if vals[0] == nil || vals[1] == nil {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	familyID := fmt.Sprint(vals[1])
+	n, err := s.redis.Exists(ctx, refreshFamilyKeyPrefix+familyID).Result()
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	userID, err := strconv.ParseInt(fmt.Sprint(vals[0]), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("refresh store: parse user_id: %w", err)
+	}
+	expiresAt, err := strconv.ParseInt(fmt.Sprint(vals[2]), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("refresh store: parse expires_at: %w", err)
+	}
+	return &domain.RefreshToken{UserID: userID, FamilyID: familyID, ExpiresAt: time.Unix(expiresAt, 0)}, nil
+}
+
+// Rotate 原子地将令牌标记为已使用
+func (s *RefreshTokenStore) Rotate(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	if s.redis == nil {
+		return nil, errors.New("refresh store: redis is nil")
+	}
+
+	res, err := rotateScript.Run(ctx, s.redis, []string{refreshKeyPrefix + tokenHash}, refreshFamilyKeyPrefix).Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	status, _ := res[0].(int64)
+	switch status {
+	case 1:
+		token, err := parseRotateResult(res)
+		if err != nil {
+			return nil, err
+		}
+		expiresAt, err := strconv.ParseInt(fmt.Sprint(res[3]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("refresh store: parse expires_at: %w", err)
+		}
+		token.ExpiresAt = time.Unix(expiresAt, 0)
+		return token, nil
+	case -1:
+		token, err := parseRotateResult(res)
+		if err != nil {
+			return nil, err
+		}
+		return token, domain.ErrRefreshTokenReused
+	default:
+		return nil, domain.ErrInvalidRefreshToken
+	}
+}
+
+// RevokeFamily 作废一个令牌家族
+func (s *RefreshTokenStore) RevokeFamily(ctx context.Context, familyID string) error {
+	if s.redis == nil {
+		return errors.New("refresh store: redis is nil")
+	}
+	return s.redis.Del(ctx, refreshFamilyKeyPrefix+familyID).Err()
+}
+
+// RevokeByUser 作废用户的全部令牌家族
+func (s *RefreshTokenStore) RevokeByUser(ctx context.Context, userID int64) error {
+	if s.redis == nil {
+		return errors.New("refresh store: redis is nil")
+	}
+
+	indexKey := userRefreshFamiliesKey(userID)
+	families, err := s.redis.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return err
+	}
+
+	pipe := s.redis.TxPipeline()
+	for _, id := range families {
+		pipe.Del(ctx, refreshFamilyKeyPrefix+id)
+	}
+	pipe.Del(ctx, indexKey)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// parseRotateResult 解析脚本返回的 user_id 与 family_id
+func parseRotateResult(res []any) (*domain.RefreshToken, error) {
+	if len(res) < 3 {
+		return nil, errors.New("refresh store: unexpected script result")
+	}
+	userID, err := strconv.ParseInt(fmt.Sprint(res[1]), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("refresh store: parse user_id: %w", err)
+	}
+	return &domain.RefreshToken{UserID: userID, FamilyID: fmt.Sprint(res[2])}, nil
+}
+
+func userRefreshFamiliesKey(userID int64) string {
+	return userRefreshFamiliesPrefix + strconv.FormatInt(userID, 10)
+}
+
+// 确保 RefreshTokenStore 实现了 domain.RefreshTokenStore 接口
+var _ domain.RefreshTokenStore = (*RefreshTokenStore)(nil)
@@ -0,0 +1,131 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"mygo/internal/user/domain"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestRefreshStore(t *testing.T) (*RefreshTokenStore, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	return &RefreshTokenStore{redis: rdb}, mr
+}
+
+func TestRefreshTokenStoreRotate(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	token := &domain.RefreshToken{UserID: 42, FamilyID: "fam-1", ExpiresAt: expiresAt}
+
+	tests := []struct {
+		name    string
+		setup   func(ctx context.Context, s *RefreshTokenStore) error
+		rotate  string
+		wantErr error
+		// familyValid 轮换后家族是否仍然有效
+		familyValid bool
+	}{
+		{
+			name:    "unknown token",
+			setup:   func(context.Context, *RefreshTokenStore) error { return nil },
+			rotate:  "missing",
+			wantErr: domain.ErrInvalidRefreshToken,
+		},
+		{
+			name: "first use succeeds",
+			setup: func(ctx context.Context, s *RefreshTokenStore) error {
+				return s.Save(ctx, "a", token)
+			},
+			rotate:      "a",
+			familyValid: true,
+		},
+		{
+			name: "reuse revokes the family",
+			setup: func(ctx context.Context, s *RefreshTokenStore) error {
+				if err := s.Save(ctx, "a", token); err != nil {
+					return err
+				}
+				_, err := s.Rotate(ctx, "a")
+				return err
+			},
+			rotate:  "a",
+			wantErr: domain.ErrRefreshTokenReused,
+		},
+		{
+			name: "token of a revoked family",
+			setup: func(ctx context.Context, s *RefreshTokenStore) error {
+				if err := s.Save(ctx, "a", token); err != nil {
+					return err
+				}
+				return s.RevokeFamily(ctx, token.FamilyID)
+			},
+			rotate:  "a",
+			wantErr: domain.ErrInvalidRefreshToken,
+		},
+		{
+			name: "token of a user whose families were revoked",
+			setup: func(ctx context.Context, s *RefreshTokenStore) error {
+				if err := s.Save(ctx, "a", token); err != nil {
+					return err
+				}
+				return s.RevokeByUser(ctx, token.UserID)
+			},
+			rotate:  "a",
+			wantErr: domain.ErrInvalidRefreshToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s, mr := newTestRefreshStore(t)
+			if err := tt.setup(ctx, s); err != nil {
+				t.Fatalf("setup: %v", err)
+			}
+
+			got, err := s.Rotate(ctx, tt.rotate)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Rotate() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil || errors.Is(err, domain.ErrRefreshTokenReused) {
+				if got == nil || got.UserID != token.UserID || got.FamilyID != token.FamilyID {
+					t.Fatalf("Rotate() = %+v, want user %d family %s", got, token.UserID, token.FamilyID)
+				}
+			}
+			if err == nil && !got.ExpiresAt.Equal(expiresAt) {
+				t.Errorf("Rotate() expires_at = %v, want %v", got.ExpiresAt, expiresAt)
+			}
+			if valid := mr.Exists(refreshFamilyKeyPrefix + token.FamilyID); valid != tt.familyValid {
+				t.Errorf("family valid = %v, want %v", valid, tt.familyValid)
+			}
+		})
+	}
+}
+
+func TestRefreshTokenStoreRotateIsSingleUse(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestRefreshStore(t)
+	token := &domain.RefreshToken{UserID: 7, FamilyID: "fam", ExpiresAt: time.Now().Add(time.Hour)}
+	for _, hash := range []string{"old", "new"} {
+		if err := s.Save(ctx, hash, token); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := s.Rotate(ctx, "old"); err != nil {
+		t.Fatalf("first Rotate() error = %v", err)
+	}
+	if _, err := s.Rotate(ctx, "old"); !errors.Is(err, domain.ErrRefreshTokenReused) {
+		t.Fatalf("second Rotate() error = %v, want ErrRefreshTokenReused", err)
+	}
+	// 重复使用作废整个家族，同家族中尚未使用的令牌随之失效
+	if _, err := s.Rotate(ctx, "new"); !errors.Is(err, domain.ErrInvalidRefreshToken) {
+		t.Fatalf("Rotate() of sibling error = %v, want ErrInvalidRefreshToken", err)
+	}
+}
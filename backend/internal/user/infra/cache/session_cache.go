@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"mygo/internal/infra"
@@ -12,11 +13,13 @@ import (
 )
 
 const (
-	sessionKeyPrefix = "session:"
-	sessionTTL       = 24 * time.Hour
+	sessionKeyPrefix     = "session:"
+	userSessionKeyPrefix = "user_sessions:"
+	sessionTTL           = 24 * time.Hour
 )
 
-// SessionCache 会话缓存实现
+// SessionCache 会话缓存实现。
+// 除 session:<id> 外，还维护 user_sessions:<user_id> 集合作为用户的会话索引。
 type SessionCache struct {
 	redis *infra.RedisClient
 }
@@ -44,7 +47,13 @@ func (c *SessionCache) Set(ctx context.Context, sessionID string, data *domain.S
 		return fmt.Errorf("session cache: marshal error: %w", err)
 	}
 
-	return c.redis.Set(ctx, key, jsonData, sessionTTL).Err()
+	indexKey := userSessionKey(data.UserID)
+	pipe := c.redis.TxPipeline()
+	pipe.Set(ctx, key, jsonData, sessionTTL)
+	pipe.SAdd(ctx, indexKey, sessionID)
+	pipe.Expire(ctx, indexKey, sessionTTL)
+	_, err = pipe.Exec(ctx)
+	return err
 }
 
 // Get 获取会话
@@ -56,6 +65,9 @@ func (c *SessionCache) Get(ctx context.Context, sessionID string) (*domain.Sessi
 	key := sessionKeyPrefix + sessionID
 	val, err := c.redis.Get(ctx, key).Result()
 	if err != nil {
+		if errors.Is(err, infra.ErrRedisNil) {
+			return nil, domain.ErrSessionNotFound
+		}
 		return nil, err
 	}
 
@@ -73,18 +85,113 @@ func (c *SessionCache) Delete(ctx context.Context, sessionID string) error {
 		return errors.New("session cache: redis is nil")
 	}
 
-	key := sessionKeyPrefix + sessionID
-	return c.redis.Del(ctx, key).Err()
+	data, err := c.Get(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	pipe := c.redis.TxPipeline()
+	pipe.Del(ctx, sessionKeyPrefix+sessionID)
+	pipe.SRem(ctx, userSessionKey(data.UserID), sessionID)
+	_, err = pipe.Exec(ctx)
+	return err
 }
 
-// Refresh 刷新会话过期时间
+// Refresh 刷新会话及用户会话索引的过期时间
 func (c *SessionCache) Refresh(ctx context.Context, sessionID string) error {
 	if c.redis == nil {
 		return errors.New("session cache: redis is nil")
 	}
 
-	key := sessionKeyPrefix + sessionID
-	return c.redis.Expire(ctx, key, sessionTTL).Err()
+	data, err := c.Get(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	pipe := c.redis.TxPipeline()
+	pipe.Expire(ctx, sessionKeyPrefix+sessionID, sessionTTL)
+	pipe.Expire(ctx, userSessionKey(data.UserID), sessionTTL)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// ListByUser 列出用户的有效会话，顺带清理索引中已过期的会话
+func (c *SessionCache) ListByUser(ctx context.Context, userID int64) (map[string]*domain.SessionData, error) {
+	if c.redis == nil {
+		return nil, errors.New("session cache: redis is nil")
+	}
+
+	indexKey := userSessionKey(userID)
+	ids, err := c.redis.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make(map[string]*domain.SessionData, len(ids))
+	var stale []any
+	for _, id := range ids {
+		data, err := c.Get(ctx, id)
+		if err != nil {
+			if errors.Is(err, domain.ErrSessionNotFound) {
+				stale = append(stale, id)
+				continue
+			}
+			return nil, err
+		}
+		sessions[id] = data
+	}
+
+	if len(stale) > 0 {
+		if err := c.redis.SRem(ctx, indexKey, stale...).Err(); err != nil {
+			return nil, err
+		}
+	}
+	return sessions, nil
+}
+
+// Count 统计当前有效会话数。使用 SCAN 遍历，代价随 key 数量增长，仅供监控低频调用
+func (c *SessionCache) Count(ctx context.Context) (int64, error) {
+	if c.redis == nil {
+		return 0, errors.New("session cache: redis is nil")
+	}
+
+	var n int64
+	iter := c.redis.Scan(ctx, 0, sessionKeyPrefix+"*", 1000).Iterator()
+	for iter.Next(ctx) {
+		n++
+	}
+	return n, iter.Err()
+}
+
+// DeleteByUser 删除用户的全部会话，exceptSessionID 非空时保留该会话
+func (c *SessionCache) DeleteByUser(ctx context.Context, userID int64, exceptSessionID string) error {
+	if c.redis == nil {
+		return errors.New("session cache: redis is nil")
+	}
+
+	indexKey := userSessionKey(userID)
+	ids, err := c.redis.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return err
+	}
+
+	pipe := c.redis.TxPipeline()
+	for _, id := range ids {
+		if id == exceptSessionID {
+			continue
+		}
+		pipe.Del(ctx, sessionKeyPrefix+id)
+		pipe.SRem(ctx, indexKey, id)
+	}
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func userSessionKey(userID int64) string {
+	return userSessionKeyPrefix + strconv.FormatInt(userID, 10)
 }
 
 // 确保 SessionCache 实现了 domain.SessionCache 接口
@@ -0,0 +1,139 @@
+package hasher
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Argon2Params Argon2id 参数
+type Argon2Params struct {
+	Memory      uint32 // KiB
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultArgon2Params 默认参数（64 MiB, t=3, p=2）
+func DefaultArgon2Params() Argon2Params {
+	return Argon2Params{
+		Memory:      64 * 1024,
+		Iterations:  3,
+		Parallelism: 2,
+		SaltLength:  16,
+		KeyLength:   32,
+	}
+}
+
+// Argon2id Argon2id 算法，哈希为 PHC 格式：
+// $argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>（无填充 base64）
+type Argon2id struct {
+	params Argon2Params
+}
+
+// NewArgon2id 创建 Argon2id 算法，零值字段使用默认参数
+func NewArgon2id(params Argon2Params) (*Argon2id, error) {
+	def := DefaultArgon2Params()
+	if params.Memory == 0 {
+		params.Memory = def.Memory
+	}
+	if params.Iterations == 0 {
+		params.Iterations = def.Iterations
+	}
+	if params.Parallelism == 0 {
+		params.Parallelism = def.Parallelism
+	}
+	if params.SaltLength == 0 {
+		params.SaltLength = def.SaltLength
+	}
+	if params.KeyLength == 0 {
+		params.KeyLength = def.KeyLength
+	}
+	if params.Memory < 8*uint32(params.Parallelism) {
+		return nil, errors.New("hasher: argon2id memory must be at least 8*parallelism KiB")
+	}
+	return &Argon2id{params: params}, nil
+}
+
+func (a *Argon2id) Name() string { return "argon2id" }
+
+func (a *Argon2id) Matches(encoded string) bool {
+	return strings.HasPrefix(encoded, "$argon2id$")
+}
+
+func (a *Argon2id) Hash(password string) (string, error) {
+	salt := make([]byte, a.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("hasher: argon2id salt: %w", err)
+	}
+
+	p := a.params
+	key := argon2.IDKey([]byte(password), salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, p.Memory, p.Iterations, p.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (a *Argon2id) Verify(password, encoded string) (bool, error) {
+	p, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	other := argon2.IDKey([]byte(password), salt, p.Iterations, p.Memory, p.Parallelism, uint32(len(key)))
+	return subtle.ConstantTimeCompare(key, other) == 1, nil
+}
+
+func (a *Argon2id) Outdated(encoded string) bool {
+	p, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return true
+	}
+	return p.Memory < a.params.Memory ||
+		p.Iterations < a.params.Iterations ||
+		p.Parallelism < a.params.Parallelism ||
+		uint32(len(salt)) < a.params.SaltLength ||
+		uint32(len(key)) < a.params.KeyLength
+}
+
+// decodeArgon2id 解析 PHC 格式的 Argon2id 哈希
+func decodeArgon2id(encoded string) (Argon2Params, []byte, []byte, error) {
+	var p Argon2Params
+
+	// "", "argon2id", "v=19", "m=..,t=..,p=..", salt, hash
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return p, nil, nil, errors.New("hasher: invalid argon2id hash")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return p, nil, nil, fmt.Errorf("hasher: invalid argon2id version: %w", err)
+	}
+	if version != argon2.Version {
+		return p, nil, nil, fmt.Errorf("hasher: unsupported argon2id version %d", version)
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Iterations, &p.Parallelism); err != nil {
+		return p, nil, nil, fmt.Errorf("hasher: invalid argon2id params: %w", err)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return p, nil, nil, fmt.Errorf("hasher: invalid argon2id salt: %w", err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return p, nil, nil, fmt.Errorf("hasher: invalid argon2id hash: %w", err)
+	}
+	return p, salt, key, nil
+}
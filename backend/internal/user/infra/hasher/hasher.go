@@ -0,0 +1,91 @@
+package hasher
+
+import (
+	"errors"
+	"fmt"
+
+	"mygo/internal/user/domain"
+)
+
+// ErrUnknownAlgorithm 无法识别的哈希格式
+var ErrUnknownAlgorithm = errors.New("hasher: unknown hash algorithm")
+
+// Algorithm 单一哈希算法实现
+type Algorithm interface {
+	// Name 算法名，如 "argon2id"、"bcrypt"
+	Name() string
+	// Matches 判断编码后的哈希是否属于本算法
+	Matches(encoded string) bool
+	Hash(password string) (string, error)
+	Verify(password, encoded string) (bool, error)
+	// Outdated 判断哈希参数是否弱于当前配置
+	Outdated(encoded string) bool
+}
+
+// Hasher 组合多种算法：新哈希使用首选算法，校验时按格式选择算法，
+// 非首选算法或参数过时的哈希会被标记为需要重新哈希。
+type Hasher struct {
+	preferred  Algorithm
+	algorithms []Algorithm
+}
+
+// New 创建 Hasher，legacy 为仍需支持校验的旧算法
+func New(preferred Algorithm, legacy ...Algorithm) *Hasher {
+	return &Hasher{
+		preferred:  preferred,
+		algorithms: append([]Algorithm{preferred}, legacy...),
+	}
+}
+
+// Config 哈希配置
+type Config struct {
+	// Algorithm 首选算法：argon2id 或 bcrypt
+	Algorithm  string
+	BcryptCost int
+	Argon2     Argon2Params
+}
+
+// NewFromConfig 根据配置创建 Hasher，另一种算法作为旧算法保留校验能力
+func NewFromConfig(cfg Config) (*Hasher, error) {
+	bc, err := NewBcrypt(cfg.BcryptCost)
+	if err != nil {
+		return nil, err
+	}
+	a2, err := NewArgon2id(cfg.Argon2)
+	if err != nil {
+		return nil, err
+	}
+
+	switch cfg.Algorithm {
+	case "", a2.Name():
+		return New(a2, bc), nil
+	case bc.Name():
+		return New(bc, a2), nil
+	default:
+		return nil, fmt.Errorf("hasher: unsupported algorithm %q", cfg.Algorithm)
+	}
+}
+
+// Hash 使用首选算法哈希密码
+func (h *Hasher) Hash(password string) (string, error) {
+	return h.preferred.Hash(password)
+}
+
+// Verify 校验密码，并返回是否需要使用首选算法重新哈希
+func (h *Hasher) Verify(password, encoded string) (bool, bool, error) {
+	for _, alg := range h.algorithms {
+		if !alg.Matches(encoded) {
+			continue
+		}
+		ok, err := alg.Verify(password, encoded)
+		if err != nil || !ok {
+			return false, false, err
+		}
+		needsRehash := alg != h.preferred || alg.Outdated(encoded)
+		return true, needsRehash, nil
+	}
+	return false, false, ErrUnknownAlgorithm
+}
+
+// 确保 Hasher 实现了 domain.PasswordHasher 接口
+var _ domain.PasswordHasher = (*Hasher)(nil)
@@ -0,0 +1,238 @@
+package hasher
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testArgon2Params 测试用的小参数，避免默认 64 MiB 拖慢测试
+var testArgon2Params = Argon2Params{Memory: 64, Iterations: 2, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+func newTestArgon2id(t *testing.T, params Argon2Params) *Argon2id {
+	t.Helper()
+	a, err := NewArgon2id(params)
+	if err != nil {
+		t.Fatalf("NewArgon2id: %v", err)
+	}
+	return a
+}
+
+func TestDecodeArgon2id(t *testing.T) {
+	const salt = "c2FsdHNhbHRzYWx0c2FsdA"
+	const key = "a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2U"
+
+	tests := []struct {
+		name    string
+		encoded string
+		wantErr bool
+		want    Argon2Params
+	}{
+		{
+			name:    "valid",
+			encoded: "$argon2id$v=19$m=64,t=1,p=1$" + salt + "$" + key,
+			want:    Argon2Params{Memory: 64, Iterations: 1, Parallelism: 1},
+		},
+		{name: "empty", encoded: "", wantErr: true},
+		{name: "wrong algorithm", encoded: "$argon2i$v=19$m=64,t=1,p=1$" + salt + "$" + key, wantErr: true},
+		{name: "truncated", encoded: "$argon2id$v=19$m=64,t=1,p=1$" + salt, wantErr: true},
+		{name: "extra segment", encoded: "$argon2id$v=19$m=64,t=1,p=1$" + salt + "$" + key + "$x", wantErr: true},
+		{name: "wrong version", encoded: "$argon2id$v=16$m=64,t=1,p=1$" + salt + "$" + key, wantErr: true},
+		{name: "malformed version", encoded: "$argon2id$version$m=64,t=1,p=1$" + salt + "$" + key, wantErr: true},
+		{name: "malformed params", encoded: "$argon2id$v=19$m=64;t=1;p=1$" + salt + "$" + key, wantErr: true},
+		{name: "invalid salt", encoded: "$argon2id$v=19$m=64,t=1,p=1$!!!$" + key, wantErr: true},
+		{name: "invalid hash", encoded: "$argon2id$v=19$m=64,t=1,p=1$" + salt + "$!!!", wantErr: true},
+		{name: "padded base64", encoded: "$argon2id$v=19$m=64,t=1,p=1$" + salt + "==$" + key, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, gotSalt, gotKey, err := decodeArgon2id(tt.encoded)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("decodeArgon2id(%q) = nil error, want error", tt.encoded)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeArgon2id: %v", err)
+			}
+			if p != tt.want {
+				t.Errorf("params = %+v, want %+v", p, tt.want)
+			}
+			if len(gotSalt) != 16 || len(gotKey) != 29 {
+				t.Errorf("len(salt), len(key) = %d, %d, want 16, 29", len(gotSalt), len(gotKey))
+			}
+		})
+	}
+}
+
+func TestArgon2idOutdated(t *testing.T) {
+	current := newTestArgon2id(t, testArgon2Params)
+
+	hashWith := func(t *testing.T, params Argon2Params) string {
+		t.Helper()
+		encoded, err := newTestArgon2id(t, params).Hash("password")
+		if err != nil {
+			t.Fatalf("Hash: %v", err)
+		}
+		return encoded
+	}
+	with := func(modify func(*Argon2Params)) Argon2Params {
+		p := testArgon2Params
+		modify(&p)
+		return p
+	}
+
+	tests := []struct {
+		name    string
+		encoded func(t *testing.T) string
+		want    bool
+	}{
+		{
+			name:    "same params",
+			encoded: func(t *testing.T) string { return hashWith(t, testArgon2Params) },
+		},
+		{
+			name: "stronger params",
+			encoded: func(t *testing.T) string {
+				return hashWith(t, with(func(p *Argon2Params) { p.Memory = 128; p.Iterations = 3 }))
+			},
+		},
+		{
+			name:    "lower memory",
+			encoded: func(t *testing.T) string { return hashWith(t, with(func(p *Argon2Params) { p.Memory = 32 })) },
+			want:    true,
+		},
+		{
+			name:    "fewer iterations",
+			encoded: func(t *testing.T) string { return hashWith(t, with(func(p *Argon2Params) { p.Iterations = 1 })) },
+			want:    true,
+		},
+		{
+			name:    "shorter salt",
+			encoded: func(t *testing.T) string { return hashWith(t, with(func(p *Argon2Params) { p.SaltLength = 8 })) },
+			want:    true,
+		},
+		{
+			name:    "shorter key",
+			encoded: func(t *testing.T) string { return hashWith(t, with(func(p *Argon2Params) { p.KeyLength = 16 })) },
+			want:    true,
+		},
+		{
+			name:    "malformed",
+			encoded: func(*testing.T) string { return "$argon2id$v=19$garbage" },
+			want:    true,
+		},
+		{
+			name:    "wrong version",
+			encoded: func(*testing.T) string { return "$argon2id$v=16$m=64,t=1,p=1$c2FsdA$a2V5" },
+			want:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded := tt.encoded(t)
+			if got := current.Outdated(encoded); got != tt.want {
+				t.Errorf("Outdated(%q) = %v, want %v", encoded, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasherVerify(t *testing.T) {
+	argon := newTestArgon2id(t, testArgon2Params)
+	weakArgon := newTestArgon2id(t, Argon2Params{Memory: 32, Iterations: 2, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+	bc, err := NewBcrypt(bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("NewBcrypt: %v", err)
+	}
+	h := New(argon, bc)
+
+	mustHash := func(t *testing.T, alg Algorithm) string {
+		t.Helper()
+		encoded, err := alg.Hash("correct horse")
+		if err != nil {
+			t.Fatalf("Hash: %v", err)
+		}
+		return encoded
+	}
+
+	tests := []struct {
+		name            string
+		encoded         func(t *testing.T) string
+		password        string
+		wantOK          bool
+		wantNeedsRehash bool
+		wantErr         error
+	}{
+		{
+			name:     "preferred algorithm",
+			encoded:  func(t *testing.T) string { return mustHash(t, argon) },
+			password: "correct horse",
+			wantOK:   true,
+		},
+		{
+			name:     "preferred algorithm wrong password",
+			encoded:  func(t *testing.T) string { return mustHash(t, argon) },
+			password: "wrong",
+		},
+		{
+			// 旧参数哈希校验通过后需要重新哈希，重新哈希后不再需要（见循环末尾）
+			name:            "outdated argon2id params",
+			encoded:         func(t *testing.T) string { return mustHash(t, weakArgon) },
+			password:        "correct horse",
+			wantOK:          true,
+			wantNeedsRehash: true,
+		},
+		{
+			name:            "legacy bcrypt",
+			encoded:         func(t *testing.T) string { return mustHash(t, bc) },
+			password:        "correct horse",
+			wantOK:          true,
+			wantNeedsRehash: true,
+		},
+		{
+			name:     "legacy bcrypt wrong password",
+			encoded:  func(t *testing.T) string { return mustHash(t, bc) },
+			password: "wrong",
+		},
+		{
+			name:     "unknown algorithm",
+			encoded:  func(*testing.T) string { return "plaintext" },
+			password: "plaintext",
+			wantErr:  ErrUnknownAlgorithm,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded := tt.encoded(t)
+			ok, needsRehash, err := h.Verify(tt.password, encoded)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify err = %v, want %v", err, tt.wantErr)
+			}
+			if ok != tt.wantOK || needsRehash != tt.wantNeedsRehash {
+				t.Fatalf("Verify = (%v, %v), want (%v, %v)", ok, needsRehash, tt.wantOK, tt.wantNeedsRehash)
+			}
+			if !needsRehash {
+				return
+			}
+
+			rehashed, err := h.Hash(tt.password)
+			if err != nil {
+				t.Fatalf("Hash: %v", err)
+			}
+			if !strings.HasPrefix(rehashed, "$argon2id$") {
+				t.Fatalf("rehashed = %q, want argon2id", rehashed)
+			}
+			ok, needsRehash, err = h.Verify(tt.password, rehashed)
+			if err != nil || !ok || needsRehash {
+				t.Fatalf("Verify(rehashed) = (%v, %v, %v), want (true, false, nil)", ok, needsRehash, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package jwt
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"mygo/internal/user/domain"
+)
+
+const (
+	// DefaultAccessTTL 访问令牌默认有效期
+	DefaultAccessTTL = 15 * time.Minute
+
+	// clockSkew 校验时间声明允许的时钟偏差
+	clockSkew = 30 * time.Second
+)
+
+// Config 签发配置
+type Config struct {
+	Issuer    string
+	Audience  string
+	AccessTTL time.Duration
+}
+
+// Issuer 实现 domain.AccessTokenIssuer
+type Issuer struct {
+	keys *KeySet
+	cfg  Config
+	now  func() time.Time
+}
+
+// NewIssuer 构造函数
+func NewIssuer(keys *KeySet, cfg Config) (*Issuer, error) {
+	if keys == nil {
+		return nil, errors.New("jwt: key set is nil")
+	}
+	if cfg.Issuer == "" {
+		return nil, errors.New("jwt: issuer is required")
+	}
+	if cfg.AccessTTL <= 0 {
+		cfg.AccessTTL = DefaultAccessTTL
+	}
+	return &Issuer{keys: keys, cfg: cfg, now: time.Now}, nil
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+	Kid string `json:"kid"`
+}
+
+type payload struct {
+	Issuer    string   `json:"iss"`
+	Subject   string   `json:"sub"`
+	Audience  string   `json:"aud,omitempty"`
+	IssuedAt  int64    `json:"iat"`
+	NotBefore int64    `json:"nbf"`
+	ExpiresAt int64    `json:"exp"`
+	ID        string   `json:"jti"`
+	Username  string   `json:"username"`
+	Roles     []string `json:"roles"`
+}
+
+// Issue 签发访问令牌
+func (i *Issuer) Issue(claims *domain.AccessClaims) (string, error) {
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+	now := i.now().Truncate(time.Second)
+	claims.ID = hex.EncodeToString(jti)
+	claims.IssuedAt = now
+	claims.ExpiresAt = now.Add(i.cfg.AccessTTL)
+
+	roles := make([]string, 0, len(claims.Roles))
+	for _, r := range claims.Roles {
+		roles = append(roles, string(r))
+	}
+
+	key := i.keys.Active()
+	h, err := json.Marshal(header{Alg: key.Alg, Typ: "JWT", Kid: key.ID})
+	if err != nil {
+		return "", err
+	}
+	p, err := json.Marshal(payload{
+		Issuer:    i.cfg.Issuer,
+		Subject:   strconv.FormatInt(claims.UserID, 10),
+		Audience:  i.cfg.Audience,
+		IssuedAt:  now.Unix(),
+		NotBefore: now.Unix(),
+		ExpiresAt: claims.ExpiresAt.Unix(),
+		ID:        claims.ID,
+		Username:  claims.Username,
+		Roles:     roles,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	input := b64(h) + "." + b64(p)
+	sig, err := key.sign([]byte(input))
+	if err != nil {
+		return "", err
+	}
+	return input + "." + b64(sig), nil
+}
+
+// Verify 校验访问令牌。密钥由 kid 选择，且 alg 必须与密钥一致，防止算法混淆。
+func (i *Issuer) Verify(token string) (*domain.AccessClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, domain.ErrUnauthenticated
+	}
+	key, ok := i.keys.Lookup(h.Kid)
+	if !ok || h.Alg != key.Alg {
+		return nil, domain.ErrUnauthenticated
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !key.verify([]byte(parts[0]+"."+parts[1]), sig) {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	var p payload
+	if err := decodeSegment(parts[1], &p); err != nil {
+		return nil, domain.ErrUnauthenticated
+	}
+	now := i.now()
+	switch {
+	case p.Issuer != i.cfg.Issuer:
+		return nil, domain.ErrUnauthenticated
+	case i.cfg.Audience != "" && p.Audience != i.cfg.Audience:
+		return nil, domain.ErrUnauthenticated
+	case now.After(time.Unix(p.ExpiresAt, 0).Add(clockSkew)):
+		return nil, domain.ErrUnauthenticated
+	case now.Add(clockSkew).Before(time.Unix(p.NotBefore, 0)):
+		return nil, domain.ErrUnauthenticated
+	}
+
+	userID, err := strconv.ParseInt(p.Subject, 10, 64)
+	if err != nil || userID == 0 {
+		return nil, domain.ErrUnauthenticated
+	}
+	roles := make([]domain.Role, 0, len(p.Roles))
+	for _, r := range p.Roles {
+		roles = append(roles, domain.Role(r))
+	}
+	return &domain.AccessClaims{
+		ID:        p.ID,
+		UserID:    userID,
+		Username:  p.Username,
+		Roles:     roles,
+		IssuedAt:  time.Unix(p.IssuedAt, 0),
+		ExpiresAt: time.Unix(p.ExpiresAt, 0),
+	}, nil
+}
+
+// JWKS 返回公开的 JSON Web Key Set
+func (i *Issuer) JWKS() ([]byte, error) {
+	return i.keys.JWKS()
+}
+
+// decodeSegment 解码 base64url 编码的 JSON 片段
+func decodeSegment(seg string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func sha256Sum(b []byte) []byte {
+	sum := sha256.Sum256(b)
+	return sum[:]
+}
+
+// 确保 Issuer 实现了 domain.AccessTokenIssuer 接口
+var _ domain.AccessTokenIssuer = (*Issuer)(nil)
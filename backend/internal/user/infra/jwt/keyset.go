@@ -0,0 +1,179 @@
+// Package jwt 基于标准库实现 JWT 访问令牌的签发与校验（EdDSA / RS256）。
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+// 支持的签名算法
+const (
+	AlgEdDSA = "EdDSA"
+	AlgRS256 = "RS256"
+)
+
+// minRSABits RSA 密钥最小长度
+const minRSABits = 2048
+
+// Key 签名密钥
+type Key struct {
+	ID     string
+	Alg    string
+	signer crypto.Signer
+}
+
+// NewKey 由私钥创建签名密钥，算法由密钥类型决定
+func NewKey(kid string, priv crypto.Signer) (*Key, error) {
+	if kid == "" {
+		return nil, errors.New("jwt: key id is required")
+	}
+	switch k := priv.(type) {
+	case ed25519.PrivateKey:
+		return &Key{ID: kid, Alg: AlgEdDSA, signer: k}, nil
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < minRSABits {
+			return nil, fmt.Errorf("jwt: rsa key %q must be at least %d bits", kid, minRSABits)
+		}
+		return &Key{ID: kid, Alg: AlgRS256, signer: k}, nil
+	default:
+		return nil, fmt.Errorf("jwt: unsupported key type %T for %q", priv, kid)
+	}
+}
+
+// LoadKeyFile 从 PEM 文件加载私钥（PKCS#8，RSA 也接受 PKCS#1）
+func LoadKeyFile(kid, path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("jwt: read key %q: %w", kid, err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("jwt: key %q: no PEM block found", kid)
+	}
+
+	var priv any
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		priv, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		priv, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("jwt: parse key %q: %w", kid, err)
+	}
+	signer, ok := priv.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("jwt: key %q is not a signing key", kid)
+	}
+	return NewKey(kid, signer)
+}
+
+// GenerateKey 生成临时 Ed25519 密钥，kid 随机
+func GenerateKey() (*Key, error) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	return NewKey(hex.EncodeToString(id), priv)
+}
+
+// sign 对签名输入计算签名
+func (k *Key) sign(input []byte) ([]byte, error) {
+	switch k.Alg {
+	case AlgEdDSA:
+		return k.signer.Sign(rand.Reader, input, crypto.Hash(0))
+	case AlgRS256:
+		return k.signer.Sign(rand.Reader, sha256Sum(input), crypto.SHA256)
+	default:
+		return nil, fmt.Errorf("jwt: unsupported alg %q", k.Alg)
+	}
+}
+
+// verify 校验签名
+func (k *Key) verify(input, sig []byte) bool {
+	switch pub := k.signer.Public().(type) {
+	case ed25519.PublicKey:
+		return ed25519.Verify(pub, input, sig)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(pub, crypto.SHA256, sha256Sum(input), sig) == nil
+	default:
+		return false
+	}
+}
+
+// jwk 公钥的 JSON Web Key 表示
+func (k *Key) jwk() map[string]string {
+	m := map[string]string{"kid": k.ID, "alg": k.Alg, "use": "sig"}
+	switch pub := k.signer.Public().(type) {
+	case ed25519.PublicKey:
+		m["kty"] = "OKP"
+		m["crv"] = "Ed25519"
+		m["x"] = b64(pub)
+	case *rsa.PublicKey:
+		m["kty"] = "RSA"
+		m["n"] = b64(pub.N.Bytes())
+		m["e"] = b64(big.NewInt(int64(pub.E)).Bytes())
+	}
+	return m
+}
+
+// KeySet 密钥集合：Active 用于签发，其余密钥仅用于校验轮换前签发的令牌。
+// 轮换时先把新密钥加入集合并发布 JWKS，再切换 Active，旧密钥在令牌过期后移除。
+type KeySet struct {
+	active *Key
+	keys   map[string]*Key
+	order  []string
+}
+
+// NewKeySet 创建密钥集合，active 为签发密钥，retired 为仅校验的旧密钥
+func NewKeySet(active *Key, retired ...*Key) (*KeySet, error) {
+	if active == nil {
+		return nil, errors.New("jwt: active key is required")
+	}
+	ks := &KeySet{active: active, keys: map[string]*Key{}}
+	for _, k := range append([]*Key{active}, retired...) {
+		if _, dup := ks.keys[k.ID]; dup {
+			return nil, fmt.Errorf("jwt: duplicate key id %q", k.ID)
+		}
+		ks.keys[k.ID] = k
+		ks.order = append(ks.order, k.ID)
+	}
+	return ks, nil
+}
+
+// Active 返回当前签发密钥
+func (ks *KeySet) Active() *Key { return ks.active }
+
+// Lookup 按 kid 查找密钥
+func (ks *KeySet) Lookup(kid string) (*Key, bool) {
+	k, ok := ks.keys[kid]
+	return k, ok
+}
+
+// JWKS 返回 JSON Web Key Set
+func (ks *KeySet) JWKS() ([]byte, error) {
+	keys := make([]map[string]string, 0, len(ks.order))
+	for _, kid := range ks.order {
+		keys = append(keys, ks.keys[kid].jwk())
+	}
+	return json.Marshal(map[string]any{"keys": keys})
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,87 @@
+package persistence
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"mygo/internal/user/domain"
+)
+
+// AuthEventPO 认证审计事件（Persistence Object）。
+// 映射到表 `auth_events`，只追加；不设外键，用户删除后审计记录仍可追溯。
+// 用户被彻底清除时记录匿名化：UserID 置空，用户名、IP、User-Agent 清空。
+type AuthEventPO struct {
+	ID       int64  `gorm:"column:id;primaryKey"`
+	UserID   *int64 `gorm:"column:user_id;index:idx_auth_events_user_created,priority:1"`
+	Username string `gorm:"column:username;type:varchar(64);not null;default:''"`
+	ActorID  int64  `gorm:"column:actor_id;not null;default:0"`
+	Type     string `gorm:"column:type;type:varchar(32);not null;index"`
+	Detail   string `gorm:"column:detail;type:varchar(255);not null;default:''"`
+
+	IP        string    `gorm:"column:ip;type:varchar(64);not null;default:'';index"`
+	UserAgent string    `gorm:"column:user_agent;type:varchar(512);not null;default:''"`
+	RequestID string    `gorm:"column:request_id;type:varchar(64);not null;default:''"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime;index:idx_auth_events_user_created,priority:2;index"`
+}
+
+func (AuthEventPO) TableName() string { return "auth_events" }
+
+// authEventFromDomain 从领域模型转换为 PO
+func authEventFromDomain(e *domain.AuthEvent) *AuthEventPO {
+	if e == nil {
+		return nil
+	}
+	return &AuthEventPO{
+		ID:        e.ID,
+		UserID:    &e.UserID,
+		Username:  truncate(e.Username, 64),
+		ActorID:   e.ActorID,
+		Type:      truncate(string(e.Type), 32),
+		Detail:    truncate(e.Detail, 255),
+		IP:        truncate(e.IP, 64),
+		UserAgent: truncate(e.UserAgent, 512),
+		RequestID: truncate(e.RequestID, 64),
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+// ToDomain 转换为领域模型
+func (p *AuthEventPO) ToDomain() *domain.AuthEvent {
+	if p == nil {
+		return nil
+	}
+	var userID int64
+	if p.UserID != nil {
+		userID = *p.UserID
+	}
+	return &domain.AuthEvent{
+		ID:        p.ID,
+		UserID:    userID,
+		Username:  p.Username,
+		ActorID:   p.ActorID,
+		Type:      domain.AuthEventType(p.Type),
+		Detail:    p.Detail,
+		IP:        p.IP,
+		UserAgent: p.UserAgent,
+		RequestID: p.RequestID,
+		CreatedAt: p.CreatedAt,
+	}
+}
+
+// truncate 截断到 n 个字符（与 varchar(n) 的计数方式一致），避免客户端传入的超长字段导致写入失败。
+// 非法 UTF-8 字节替换为 U+FFFD，PostgreSQL 会拒绝写入非法编码
+func truncate(s string, n int) string {
+	s = strings.ToValidUTF8(s, "\uFFFD")
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	i := 0
+	for j := range s {
+		if i == n {
+			return s[:j]
+		}
+		i++
+	}
+	return s
+}
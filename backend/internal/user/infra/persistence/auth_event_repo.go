@@ -0,0 +1,112 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"mygo/internal/infra"
+	"mygo/internal/user/domain"
+)
+
+const maxAuthEventPageSize = 200
+
+// AuthEventRepository 认证审计事件仓储实现（只追加）
+type AuthEventRepository struct {
+	db *infra.GormDB
+}
+
+// NewAuthEventRepository 构造函数
+func NewAuthEventRepository(res *infra.Resources) (*AuthEventRepository, error) {
+	if res == nil {
+		return nil, errors.New("auth event repo: resources is nil")
+	}
+	if res.DB == nil {
+		return nil, errors.New("auth event repo: resources db is nil")
+	}
+	return &AuthEventRepository{db: res.DB}, nil
+}
+
+func (r *AuthEventRepository) Append(ctx context.Context, event *domain.AuthEvent) error {
+	if r.db == nil {
+		return errors.New("auth event repo: db is nil")
+	}
+	if event == nil {
+		return errors.New("auth event repo: event is nil")
+	}
+
+	p := authEventFromDomain(event)
+	if err := r.db.WithContext(ctx).Create(p).Error; err != nil {
+		return err
+	}
+	event.ID = p.ID
+	event.CreatedAt = p.CreatedAt
+	return nil
+}
+
+func (r *AuthEventRepository) List(ctx context.Context, filter domain.AuthEventFilter) ([]*domain.AuthEvent, int64, error) {
+	if r.db == nil {
+		return nil, 0, errors.New("auth event repo: db is nil")
+	}
+
+	q := r.db.WithContext(ctx).Model(&AuthEventPO{})
+	if filter.UserID != 0 {
+		q = q.Where("user_id = ?", filter.UserID)
+	}
+	if len(filter.Types) > 0 {
+		types := make([]string, 0, len(filter.Types))
+		for _, t := range filter.Types {
+			types = append(types, string(t))
+		}
+		q = q.Where("type IN ?", types)
+	}
+	if filter.IP != "" {
+		q = q.Where("ip = ?", filter.IP)
+	}
+	if filter.From != nil {
+		q = q.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		q = q.Where("created_at < ?", *filter.To)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	limit := filter.Limit
+	if limit <= 0 || limit > maxAuthEventPageSize {
+		limit = maxAuthEventPageSize
+	}
+
+	var pos []AuthEventPO
+	if err := q.Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(max(filter.Offset, 0)).
+		Find(&pos).Error; err != nil {
+		return nil, 0, err
+	}
+
+	events := make([]*domain.AuthEvent, 0, len(pos))
+	for i := range pos {
+		events = append(events, pos[i].ToDomain())
+	}
+	return events, total, nil
+}
+
+func (r *AuthEventRepository) AnonymizeByUser(ctx context.Context, userID int64) error {
+	if r.db == nil {
+		return errors.New("auth event repo: db is nil")
+	}
+	if userID == 0 {
+		return errors.New("auth event repo: user_id is required")
+	}
+
+	return r.db.WithContext(ctx).
+		Model(&AuthEventPO{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]any{"user_id": nil, "username": "", "ip": "", "user_agent": ""}).Error
+}
+
+// 确保 AuthEventRepository 实现了 domain.AuthEventRepository 接口
+var _ domain.AuthEventRepository = (*AuthEventRepository)(nil)
@@ -0,0 +1,134 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"mygo/internal/infra"
+	"mygo/internal/logging"
+	"mygo/internal/user/domain"
+)
+
+// maxReportedConflicts 冲突报告中最多列出的条目数
+const maxReportedConflicts = 50
+
+// identityRow 回填规范形式时读取的用户列
+type identityRow struct {
+	ID                int64
+	UserID            int64
+	Username          string
+	Email             string
+	UsernameCanonical *string
+	EmailCanonical    *string
+}
+
+// PrepareCanonicalIdentity 为由旧版本 AutoMigrate 建立的数据库回填 username_canonical / email_canonical，
+// 在 `migrate baseline` 标记版本之前执行。
+//
+// 新列先以可空方式加入，回填完成后加上 NOT NULL 与唯一索引，与 0008_users_canonical_identity 一致，
+// 之后执行该迁移时列与索引已存在，不再改动。
+// 规范形式依赖 Go 的 NFKC 与小写规则（PostgreSQL 的 lower 受数据库 locale 影响），因此不写成 SQL 迁移。
+// 已注销（软删除）的用户同样参与检查。若多个用户的规范形式相同（如 "Anon" 与 "anon"），
+// 返回列出冲突用户的错误，不做任何修改，需人工改名或合并后重新执行。
+// 重复执行是幂等的，规范化规则变化时也可用于重新回填。
+func PrepareCanonicalIdentity(ctx context.Context, db *infra.GormDB) error {
+	db = db.WithContext(ctx)
+	if !db.Migrator().HasTable(&UserPO{}) {
+		return nil
+	}
+
+	for _, col := range []string{"username_canonical", "email_canonical"} {
+		if err := db.Exec("ALTER TABLE users ADD COLUMN IF NOT EXISTS " + col + " varchar(255)").Error; err != nil {
+			return fmt.Errorf("add column %s: %w", col, err)
+		}
+	}
+
+	var rows []identityRow
+	if err := db.Unscoped().
+		Model(&UserPO{}).
+		Select("id, user_id, username, email, username_canonical, email_canonical").
+		Order("id").
+		Find(&rows).Error; err != nil {
+		return fmt.Errorf("load users: %w", err)
+	}
+
+	if err := checkIdentityConflicts(rows); err != nil {
+		return err
+	}
+
+	updated := 0
+	for _, row := range rows {
+		username := domain.CanonicalUsername(row.Username)
+		email := domain.CanonicalEmail(row.Email)
+		if equalPtr(row.UsernameCanonical, username) && equalPtr(row.EmailCanonical, email) {
+			continue
+		}
+		if err := db.Exec(
+			"UPDATE users SET username_canonical = ?, email_canonical = ? WHERE id = ?",
+			username, email, row.ID,
+		).Error; err != nil {
+			return fmt.Errorf("backfill user %d: %w", row.UserID, err)
+		}
+		updated++
+	}
+	if updated > 0 {
+		logging.FromContext(ctx).Info("backfilled canonical username/email", "users", updated)
+	}
+
+	for _, col := range []string{"username_canonical", "email_canonical"} {
+		if err := db.Exec("ALTER TABLE users ALTER COLUMN " + col + " SET NOT NULL").Error; err != nil {
+			return fmt.Errorf("set %s not null: %w", col, err)
+		}
+		if err := db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_users_" + col + " ON users (" + col + ")").Error; err != nil {
+			return fmt.Errorf("create index on %s: %w", col, err)
+		}
+	}
+	return nil
+}
+
+// checkIdentityConflicts 找出规范形式相同的用户名或邮箱
+func checkIdentityConflicts(rows []identityRow) error {
+	var conflicts []string
+	for _, field := range []struct {
+		name      string
+		canonical func(identityRow) string
+		display   func(identityRow) string
+	}{
+		{"username", func(r identityRow) string { return domain.CanonicalUsername(r.Username) }, func(r identityRow) string { return r.Username }},
+		{"email", func(r identityRow) string { return domain.CanonicalEmail(r.Email) }, func(r identityRow) string { return r.Email }},
+	} {
+		groups := make(map[string][]identityRow)
+		for _, r := range rows {
+			key := field.canonical(r)
+			groups[key] = append(groups[key], r)
+		}
+		for key, group := range groups {
+			if len(group) < 2 {
+				continue
+			}
+			parts := make([]string, 0, len(group))
+			for _, r := range group {
+				parts = append(parts, fmt.Sprintf("%q (user_id=%d)", field.display(r), r.UserID))
+			}
+			conflicts = append(conflicts, fmt.Sprintf("%s %q: %s", field.name, key, strings.Join(parts, ", ")))
+		}
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	sort.Strings(conflicts)
+	total := len(conflicts)
+	if total > maxReportedConflicts {
+		conflicts = append(conflicts[:maxReportedConflicts], fmt.Sprintf("... and %d more", total-maxReportedConflicts))
+	}
+	return errors.New("users with conflicting canonical identity, rename or merge them before migrating:\n  " +
+		strings.Join(conflicts, "\n  "))
+}
+
+func equalPtr(p *string, v string) bool {
+	return p != nil && *p == v
+}
@@ -0,0 +1,64 @@
+package persistence
+
+import (
+	"time"
+
+	"mygo/internal/user/domain"
+)
+
+// InvitationPO 注册邀请（Persistence Object）。
+// 映射到表 `invitations`，发出邀请的用户被清除时邀请一并删除。
+type InvitationPO struct {
+	ID        int64  `gorm:"column:id;primaryKey"`
+	Prefix    string `gorm:"column:prefix;type:varchar(16);not null"`
+	CodeHash  string `gorm:"column:code_hash;type:char(64);not null;uniqueIndex"`
+	CreatedBy int64  `gorm:"column:created_by;not null;index"`
+	Note      string `gorm:"column:note;type:varchar(255);not null;default:''"`
+	MaxUses   int    `gorm:"column:max_uses;not null"`
+	UsedCount int    `gorm:"column:used_count;not null;default:0"`
+
+	ExpiresAt time.Time  `gorm:"column:expires_at;not null"`
+	RevokedAt *time.Time `gorm:"column:revoked_at"`
+	CreatedAt time.Time  `gorm:"column:created_at;autoCreateTime"`
+
+	Creator *UserPO `gorm:"foreignKey:CreatedBy;references:UserID;constraint:OnDelete:CASCADE"`
+}
+
+func (InvitationPO) TableName() string { return "invitations" }
+
+// invitationFromDomain 从领域模型转换为 PO
+func invitationFromDomain(i *domain.Invitation) *InvitationPO {
+	if i == nil {
+		return nil
+	}
+	return &InvitationPO{
+		ID:        i.ID,
+		Prefix:    i.Prefix,
+		CodeHash:  i.CodeHash,
+		CreatedBy: i.CreatedBy,
+		Note:      i.Note,
+		MaxUses:   i.MaxUses,
+		UsedCount: i.UsedCount,
+		ExpiresAt: i.ExpiresAt,
+		RevokedAt: i.RevokedAt,
+	}
+}
+
+// ToDomain 转换为领域模型
+func (p *InvitationPO) ToDomain() *domain.Invitation {
+	if p == nil {
+		return nil
+	}
+	return &domain.Invitation{
+		ID:        p.ID,
+		Prefix:    p.Prefix,
+		CodeHash:  p.CodeHash,
+		CreatedBy: p.CreatedBy,
+		Note:      p.Note,
+		MaxUses:   p.MaxUses,
+		UsedCount: p.UsedCount,
+		ExpiresAt: p.ExpiresAt,
+		RevokedAt: p.RevokedAt,
+		CreatedAt: p.CreatedAt,
+	}
+}
@@ -0,0 +1,150 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"mygo/internal/infra"
+	"mygo/internal/user/domain"
+
+	"gorm.io/gorm"
+)
+
+// maxInvitationPageSize 单页最多返回的邀请数
+const maxInvitationPageSize = 200
+
+// InvitationRepository 注册邀请仓储实现
+type InvitationRepository struct {
+	db *infra.GormDB
+}
+
+// NewInvitationRepository 构造函数
+func NewInvitationRepository(res *infra.Resources) (*InvitationRepository, error) {
+	if res == nil {
+		return nil, errors.New("invitation repo: resources is nil")
+	}
+	if res.DB == nil {
+		return nil, errors.New("invitation repo: resources db is nil")
+	}
+	return &InvitationRepository{db: res.DB}, nil
+}
+
+func (r *InvitationRepository) Create(ctx context.Context, inv *domain.Invitation) error {
+	if r.db == nil {
+		return errors.New("invitation repo: db is nil")
+	}
+	if inv == nil {
+		return errors.New("invitation repo: invitation is nil")
+	}
+	if inv.CreatedBy == 0 {
+		return errors.New("invitation repo: created_by is required")
+	}
+	if inv.CodeHash == "" {
+		return errors.New("invitation repo: code_hash is required")
+	}
+
+	p := invitationFromDomain(inv)
+	if err := r.db.WithContext(ctx).Create(p).Error; err != nil {
+		return err
+	}
+	*inv = *p.ToDomain()
+	return nil
+}
+
+func (r *InvitationRepository) GetByHash(ctx context.Context, codeHash string) (*domain.Invitation, error) {
+	if r.db == nil {
+		return nil, errors.New("invitation repo: db is nil")
+	}
+
+	var p InvitationPO
+	if err := r.db.WithContext(ctx).Where("code_hash = ?", codeHash).First(&p).Error; err != nil {
+		if errors.Is(err, infra.ErrRecordNotFound) {
+			return nil, domain.ErrInvitationNotFound
+		}
+		return nil, err
+	}
+	return p.ToDomain(), nil
+}
+
+func (r *InvitationRepository) List(ctx context.Context, limit, offset int) ([]*domain.Invitation, int64, error) {
+	if r.db == nil {
+		return nil, 0, errors.New("invitation repo: db is nil")
+	}
+
+	q := r.db.WithContext(ctx).Model(&InvitationPO{})
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if limit <= 0 || limit > maxInvitationPageSize {
+		limit = maxInvitationPageSize
+	}
+
+	var pos []InvitationPO
+	if err := q.Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(max(offset, 0)).
+		Find(&pos).Error; err != nil {
+		return nil, 0, err
+	}
+
+	invitations := make([]*domain.Invitation, 0, len(pos))
+	for i := range pos {
+		invitations = append(invitations, pos[i].ToDomain())
+	}
+	return invitations, total, nil
+}
+
+func (r *InvitationRepository) Revoke(ctx context.Context, id int64, at time.Time) error {
+	if r.db == nil {
+		return errors.New("invitation repo: db is nil")
+	}
+
+	tx := r.db.WithContext(ctx).
+		Model(&InvitationPO{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return domain.ErrInvitationNotFound
+	}
+	return nil
+}
+
+func (r *InvitationRepository) Consume(ctx context.Context, id int64, now time.Time) error {
+	if r.db == nil {
+		return errors.New("invitation repo: db is nil")
+	}
+
+	// 条件更新保证并发注册时不会超出 max_uses
+	tx := r.db.WithContext(ctx).
+		Model(&InvitationPO{}).
+		Where("id = ? AND revoked_at IS NULL AND expires_at > ? AND used_count < max_uses", id, now).
+		Update("used_count", gorm.Expr("used_count + 1"))
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return domain.ErrInvalidInvitation
+	}
+	return nil
+}
+
+func (r *InvitationRepository) Release(ctx context.Context, id int64) error {
+	if r.db == nil {
+		return errors.New("invitation repo: db is nil")
+	}
+
+	return r.db.WithContext(ctx).
+		Model(&InvitationPO{}).
+		Where("id = ? AND used_count > 0", id).
+		Update("used_count", gorm.Expr("used_count - 1")).Error
+}
+
+// 确保 InvitationRepository 实现了 domain.InvitationRepository 接口
+var _ domain.InvitationRepository = (*InvitationRepository)(nil)
@@ -0,0 +1,75 @@
+package persistence
+
+import (
+	"strings"
+	"time"
+
+	"mygo/internal/user/domain"
+)
+
+// PersonalAccessTokenPO 个人访问令牌（Persistence Object）。
+// 映射到表 `personal_access_tokens`，scopes 以逗号分隔存储。
+type PersonalAccessTokenPO struct {
+	ID        int64  `gorm:"column:id;primaryKey"`
+	UserID    int64  `gorm:"column:user_id;not null;index"`
+	Name      string `gorm:"column:name;type:varchar(64);not null"`
+	Prefix    string `gorm:"column:prefix;type:varchar(16);not null"`
+	TokenHash string `gorm:"column:token_hash;type:char(64);not null;uniqueIndex"`
+	Scopes    string `gorm:"column:scopes;type:varchar(255);not null;default:''"`
+
+	ExpiresAt  time.Time  `gorm:"column:expires_at;not null"`
+	LastUsedAt *time.Time `gorm:"column:last_used_at"`
+	RevokedAt  *time.Time `gorm:"column:revoked_at"`
+	CreatedAt  time.Time  `gorm:"column:created_at;autoCreateTime"`
+
+	User *UserPO `gorm:"foreignKey:UserID;references:UserID;constraint:OnDelete:CASCADE"`
+}
+
+func (PersonalAccessTokenPO) TableName() string { return "personal_access_tokens" }
+
+// tokenFromDomain 从领域模型转换为 PO
+func tokenFromDomain(t *domain.PersonalAccessToken) *PersonalAccessTokenPO {
+	if t == nil {
+		return nil
+	}
+	scopes := make([]string, 0, len(t.Scopes))
+	for _, s := range t.Scopes {
+		scopes = append(scopes, string(s))
+	}
+	return &PersonalAccessTokenPO{
+		ID:         t.ID,
+		UserID:     t.UserID,
+		Name:       t.Name,
+		Prefix:     t.Prefix,
+		TokenHash:  t.TokenHash,
+		Scopes:     strings.Join(scopes, ","),
+		ExpiresAt:  t.ExpiresAt,
+		LastUsedAt: t.LastUsedAt,
+		RevokedAt:  t.RevokedAt,
+	}
+}
+
+// ToDomain 转换为领域模型
+func (p *PersonalAccessTokenPO) ToDomain() *domain.PersonalAccessToken {
+	if p == nil {
+		return nil
+	}
+	var scopes []domain.Permission
+	if p.Scopes != "" {
+		for _, s := range strings.Split(p.Scopes, ",") {
+			scopes = append(scopes, domain.Permission(s))
+		}
+	}
+	return &domain.PersonalAccessToken{
+		ID:         p.ID,
+		UserID:     p.UserID,
+		Name:       p.Name,
+		Prefix:     p.Prefix,
+		TokenHash:  p.TokenHash,
+		Scopes:     scopes,
+		ExpiresAt:  p.ExpiresAt,
+		LastUsedAt: p.LastUsedAt,
+		RevokedAt:  p.RevokedAt,
+		CreatedAt:  p.CreatedAt,
+	}
+}
@@ -0,0 +1,126 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"mygo/internal/infra"
+	"mygo/internal/user/domain"
+)
+
+// TokenRepository 个人访问令牌仓储实现
+type TokenRepository struct {
+	db *infra.GormDB
+}
+
+// NewTokenRepository 构造函数
+func NewTokenRepository(res *infra.Resources) (*TokenRepository, error) {
+	if res == nil {
+		return nil, errors.New("token repo: resources is nil")
+	}
+	if res.DB == nil {
+		return nil, errors.New("token repo: resources db is nil")
+	}
+	return &TokenRepository{db: res.DB}, nil
+}
+
+func (r *TokenRepository) Create(ctx context.Context, token *domain.PersonalAccessToken) error {
+	if r.db == nil {
+		return errors.New("token repo: db is nil")
+	}
+	if token == nil {
+		return errors.New("token repo: token is nil")
+	}
+	if token.UserID == 0 {
+		return errors.New("token repo: user_id is required")
+	}
+	if token.TokenHash == "" {
+		return errors.New("token repo: token_hash is required")
+	}
+
+	p := tokenFromDomain(token)
+	if err := r.db.WithContext(ctx).Create(p).Error; err != nil {
+		return err
+	}
+	*token = *p.ToDomain()
+	return nil
+}
+
+func (r *TokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.PersonalAccessToken, error) {
+	if r.db == nil {
+		return nil, errors.New("token repo: db is nil")
+	}
+
+	var p PersonalAccessTokenPO
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&p).Error; err != nil {
+		if errors.Is(err, infra.ErrRecordNotFound) {
+			return nil, domain.ErrTokenNotFound
+		}
+		return nil, err
+	}
+	return p.ToDomain(), nil
+}
+
+func (r *TokenRepository) ListByUser(ctx context.Context, userID int64) ([]*domain.PersonalAccessToken, error) {
+	if r.db == nil {
+		return nil, errors.New("token repo: db is nil")
+	}
+
+	var pos []PersonalAccessTokenPO
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&pos).Error; err != nil {
+		return nil, err
+	}
+
+	tokens := make([]*domain.PersonalAccessToken, 0, len(pos))
+	for i := range pos {
+		tokens = append(tokens, pos[i].ToDomain())
+	}
+	return tokens, nil
+}
+
+func (r *TokenRepository) Revoke(ctx context.Context, userID, tokenID int64, at time.Time) error {
+	if r.db == nil {
+		return errors.New("token repo: db is nil")
+	}
+
+	tx := r.db.WithContext(ctx).
+		Model(&PersonalAccessTokenPO{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", tokenID, userID).
+		Update("revoked_at", at)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return domain.ErrTokenNotFound
+	}
+	return nil
+}
+
+func (r *TokenRepository) TouchLastUsed(ctx context.Context, tokenID int64, at time.Time) error {
+	if r.db == nil {
+		return errors.New("token repo: db is nil")
+	}
+
+	return r.db.WithContext(ctx).
+		Model(&PersonalAccessTokenPO{}).
+		Where("id = ?", tokenID).
+		Update("last_used_at", at).Error
+}
+
+func (r *TokenRepository) RevokeAllByUser(ctx context.Context, userID int64, at time.Time) error {
+	if r.db == nil {
+		return errors.New("token repo: db is nil")
+	}
+
+	return r.db.WithContext(ctx).
+		Model(&PersonalAccessTokenPO{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", at).Error
+}
+
+// 确保 TokenRepository 实现了 domain.TokenRepository 接口
+var _ domain.TokenRepository = (*TokenRepository)(nil)
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"mygo/internal/user/domain"
+
+	"gorm.io/gorm"
 )
 
 // UserPO 是 user 的数据库存储模型（Persistence Object）。
@@ -14,11 +16,31 @@ type UserPO struct {
 
 	Username string `gorm:"column:username;type:varchar(64);not null;uniqueIndex"`
 	Email    string `gorm:"column:email;type:varchar(128);not null;uniqueIndex"`
+
+	// 规范形式（见 domain.CanonicalUsername / CanonicalEmail），唯一性与查找以此为准。
+	// NFKC 可能使字符串变长，列宽大于原始列；旧库由 baseline 前的 PrepareCanonicalIdentity 回填
+	UsernameCanonical string `gorm:"column:username_canonical;type:varchar(255);not null;uniqueIndex"`
+	EmailCanonical    string `gorm:"column:email_canonical;type:varchar(255);not null;uniqueIndex"`
+
 	Password string `gorm:"column:password;type:varchar(255);not null"`
 	Avatar   string `gorm:"column:avatar;type:varchar(255)"`
 
+	// InvitedBy 邀请人 UserID，非邀请注册为 0
+	InvitedBy int64 `gorm:"column:invited_by;not null;default:0"`
+
+	EmailVerifiedAt    *time.Time `gorm:"column:email_verified_at"`
+	DisabledAt         *time.Time `gorm:"column:disabled_at"`
+	MustChangePassword bool       `gorm:"column:must_change_password;not null;default:false"`
+
 	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime"`
+
+	// 软删除：非空时默认查询不再返回，PurgeAfter 之后由后台任务彻底删除
+	DeletedAt  gorm.DeletedAt `gorm:"column:deleted_at;index"`
+	PurgeAfter *time.Time     `gorm:"column:purge_after"`
+
+	// Roles 仅用于 Preload 读取，写入走 UserRepository.AddRole/RemoveRole
+	Roles []UserRolePO `gorm:"foreignKey:UserID;references:UserID;constraint:OnDelete:CASCADE"`
 }
 
 func (UserPO) TableName() string { return "users" }
@@ -35,6 +57,15 @@ func FromDomain(u *domain.User) *UserPO {
 		Email:    u.Email,
 		Password: u.Password,
 		Avatar:   u.Avatar,
+
+		UsernameCanonical: domain.CanonicalUsername(u.Username),
+		EmailCanonical:    domain.CanonicalEmail(u.Email),
+
+		InvitedBy: u.InvitedBy,
+
+		EmailVerifiedAt:    u.EmailVerifiedAt,
+		DisabledAt:         u.DisabledAt,
+		MustChangePassword: u.MustChangePassword,
 	}
 }
 
@@ -50,5 +81,24 @@ func (p *UserPO) ToDomain() *domain.User {
 		Email:    p.Email,
 		Password: p.Password,
 		Avatar:   p.Avatar,
+		Roles:    rolesToDomain(p.Roles),
+
+		InvitedBy: p.InvitedBy,
+
+		EmailVerifiedAt:    p.EmailVerifiedAt,
+		DisabledAt:         p.DisabledAt,
+		MustChangePassword: p.MustChangePassword,
+
+		CreatedAt:  p.CreatedAt,
+		DeletedAt:  deletedAtToDomain(p.DeletedAt),
+		PurgeAfter: p.PurgeAfter,
+	}
+}
+
+func deletedAtToDomain(d gorm.DeletedAt) *time.Time {
+	if !d.Valid {
+		return nil
 	}
+	t := d.Time
+	return &t
 }
@@ -3,13 +3,19 @@ package persistence
 import (
 	"context"
 	"errors"
+	"strings"
+	"time"
 
 	"mygo/internal/infra"
 	"mygo/internal/user/domain"
 
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
+// maxUserPageSize 单页最多返回的用户数
+const maxUserPageSize = 200
+
 // UserRepository 用户仓储实现
 type UserRepository struct {
 	db *infra.GormDB
@@ -39,7 +45,7 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 
 	p := FromDomain(user)
 	if err := r.db.WithContext(ctx).Create(p).Error; err != nil {
-		return err
+		return translateUserError(err)
 	}
 	*user = *p.ToDomain()
 	return nil
@@ -51,7 +57,22 @@ func (r *UserRepository) GetByID(ctx context.Context, id int64) (*domain.User, e
 	}
 
 	var p UserPO
-	if err := r.db.WithContext(ctx).First(&p, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Roles").First(&p, id).Error; err != nil {
+		if errors.Is(err, infra.ErrRecordNotFound) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return p.ToDomain(), nil
+}
+
+func (r *UserRepository) GetByUserID(ctx context.Context, userID int64) (*domain.User, error) {
+	if r.db == nil {
+		return nil, errors.New("user repo: db is nil")
+	}
+
+	var p UserPO
+	if err := r.db.WithContext(ctx).Preload("Roles").Where("user_id = ?", userID).First(&p).Error; err != nil {
 		if errors.Is(err, infra.ErrRecordNotFound) {
 			return nil, domain.ErrUserNotFound
 		}
@@ -66,7 +87,7 @@ func (r *UserRepository) GetByUsername(ctx context.Context, username string) (*d
 	}
 
 	var p UserPO
-	if err := r.db.WithContext(ctx).Where("username = ?", username).First(&p).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Roles").Where("username_canonical = ?", domain.CanonicalUsername(username)).First(&p).Error; err != nil {
 		if errors.Is(err, infra.ErrRecordNotFound) {
 			return nil, domain.ErrUserNotFound
 		}
@@ -81,7 +102,7 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.
 	}
 
 	var p UserPO
-	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&p).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Roles").Where("email_canonical = ?", domain.CanonicalEmail(email)).First(&p).Error; err != nil {
 		if errors.Is(err, infra.ErrRecordNotFound) {
 			return nil, domain.ErrUserNotFound
 		}
@@ -119,6 +140,13 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 		"email":    user.Email,
 		"password": user.Password,
 		"avatar":   user.Avatar,
+
+		"username_canonical": domain.CanonicalUsername(user.Username),
+		"email_canonical":    domain.CanonicalEmail(user.Email),
+
+		"email_verified_at":    user.EmailVerifiedAt,
+		"disabled_at":          user.DisabledAt,
+		"must_change_password": user.MustChangePassword,
 	}
 
 	// 使用 Postgres RETURNING，一次往返拿到更新后的行（含 updated_at）
@@ -128,24 +156,193 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 		Clauses(clause.Returning{}).
 		Updates(updates)
 	if tx.Error != nil {
-		return tx.Error
+		return translateUserError(tx.Error)
 	}
 	if tx.RowsAffected == 0 {
 		return domain.ErrUserNotFound
 	}
+	// RETURNING 不包含关联数据，保留调用方已加载的角色
+	roles := user.Roles
 	*user = *p.ToDomain()
+	user.Roles = roles
+	return nil
+}
+
+func (r *UserRepository) List(ctx context.Context, filter domain.UserFilter) ([]*domain.User, int64, error) {
+	if r.db == nil {
+		return nil, 0, errors.New("user repo: db is nil")
+	}
+
+	q := r.db.WithContext(ctx).Model(&UserPO{})
+	if filter.UsernamePrefix != "" {
+		q = q.Where("username ILIKE ?", escapeLike(filter.UsernamePrefix)+"%")
+	}
+	if filter.EmailPrefix != "" {
+		q = q.Where("email ILIKE ?", escapeLike(filter.EmailPrefix)+"%")
+	}
+	if filter.CreatedFrom != nil {
+		q = q.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		q = q.Where("created_at < ?", *filter.CreatedTo)
+	}
+	if filter.Verified != nil {
+		if *filter.Verified {
+			q = q.Where("email_verified_at IS NOT NULL")
+		} else {
+			q = q.Where("email_verified_at IS NULL")
+		}
+	}
+	if filter.Role != "" {
+		q = q.Where("EXISTS (SELECT 1 FROM user_roles ur WHERE ur.user_id = users.user_id AND ur.role = ?)", string(filter.Role))
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	limit := filter.Limit
+	if limit <= 0 || limit > maxUserPageSize {
+		limit = maxUserPageSize
+	}
+
+	var pos []UserPO
+	if err := q.Preload("Roles").
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(max(filter.Offset, 0)).
+		Find(&pos).Error; err != nil {
+		return nil, 0, err
+	}
+
+	users := make([]*domain.User, 0, len(pos))
+	for i := range pos {
+		users = append(users, pos[i].ToDomain())
+	}
+	return users, total, nil
+}
+
+// translateUserError 把 users 表的唯一约束冲突转换为 domain.ConflictError。
+// 应用层查重与写入之间存在竞态，并发注册或改名最终由唯一索引兜底。
+func translateUserError(err error) error {
+	err = infra.TranslatePGError(err)
+
+	var cerr *infra.ConstraintError
+	if !errors.As(err, &cerr) || !errors.Is(cerr.Kind, infra.ErrUniqueViolation) {
+		return err
+	}
+	switch cerr.Constraint {
+	case "idx_users_username", "idx_users_username_canonical":
+		return &domain.ConflictError{Field: "username"}
+	case "idx_users_email", "idx_users_email_canonical":
+		return &domain.ConflictError{Field: "email"}
+	default:
+		// user_id 等其他唯一约束冲突属于内部错误，保留原始信息
+		return err
+	}
+}
+
+// escapeLike 转义 LIKE 模式中的通配符
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (r *UserRepository) SoftDelete(ctx context.Context, userID int64, purgeAfter time.Time) error {
+	if r.db == nil {
+		return errors.New("user repo: db is nil")
+	}
+	if userID == 0 {
+		return errors.New("user repo: user_id is required")
+	}
+
+	tx := r.db.WithContext(ctx).
+		Model(&UserPO{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]any{
+			"deleted_at":  time.Now(),
+			"purge_after": purgeAfter,
+		})
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
 	return nil
 }
 
-func (r *UserRepository) Delete(ctx context.Context, id int64) error {
+func (r *UserRepository) Restore(ctx context.Context, userID int64) error {
 	if r.db == nil {
 		return errors.New("user repo: db is nil")
 	}
-	if id == 0 {
-		return errors.New("user repo: id is required")
+	if userID == 0 {
+		return errors.New("user repo: user_id is required")
 	}
 
-	tx := r.db.WithContext(ctx).Delete(&UserPO{}, id)
+	tx := r.db.WithContext(ctx).
+		Unscoped().
+		Model(&UserPO{}).
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Updates(map[string]any{
+			"deleted_at":  nil,
+			"purge_after": nil,
+		})
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected > 0 {
+		return nil
+	}
+
+	// 区分用户不存在与未处于注销状态
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&UserPO{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return domain.ErrUserNotFound
+	}
+	return domain.ErrUserNotDeleted
+}
+
+func (r *UserRepository) ListPendingPurge(ctx context.Context, before time.Time, limit int) ([]*domain.User, error) {
+	if r.db == nil {
+		return nil, errors.New("user repo: db is nil")
+	}
+
+	var pos []UserPO
+	if err := r.db.WithContext(ctx).
+		Unscoped().
+		Where("deleted_at IS NOT NULL AND purge_after <= ?", before).
+		Order("purge_after").
+		Limit(limit).
+		Find(&pos).Error; err != nil {
+		return nil, err
+	}
+
+	users := make([]*domain.User, 0, len(pos))
+	for i := range pos {
+		users = append(users, pos[i].ToDomain())
+	}
+	return users, nil
+}
+
+func (r *UserRepository) Purge(ctx context.Context, userID int64) error {
+	if r.db == nil {
+		return errors.New("user repo: db is nil")
+	}
+	if userID == 0 {
+		return errors.New("user repo: user_id is required")
+	}
+
+	// 物理删除，user_roles / personal_access_tokens 通过外键级联删除
+	tx := r.db.WithContext(ctx).
+		Unscoped().
+		Where("user_id = ?", userID).
+		Delete(&UserPO{})
 	if tx.Error != nil {
 		return tx.Error
 	}
@@ -155,5 +352,54 @@ func (r *UserRepository) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+func (r *UserRepository) AddRole(ctx context.Context, userID int64, role domain.Role) error {
+	if r.db == nil {
+		return errors.New("user repo: db is nil")
+	}
+	if userID == 0 {
+		return errors.New("user repo: user_id is required")
+	}
+
+	p := UserRolePO{UserID: userID, Role: string(role)}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&p).Error
+}
+
+func (r *UserRepository) RemoveRole(ctx context.Context, userID int64, role domain.Role) error {
+	if r.db == nil {
+		return errors.New("user repo: db is nil")
+	}
+	if userID == 0 {
+		return errors.New("user repo: user_id is required")
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if role == domain.RoleAdmin {
+			// 锁定全部可登录管理员的 admin 角色行，并发撤销不同管理员时后者等待前者提交后重新检查
+			var admins []int64
+			if err := tx.Raw(`
+SELECT ur.user_id FROM user_roles ur
+JOIN users u ON u.user_id = ur.user_id
+WHERE ur.role = ? AND u.disabled_at IS NULL AND u.deleted_at IS NULL
+FOR UPDATE OF ur`, string(domain.RoleAdmin)).Scan(&admins).Error; err != nil {
+				return err
+			}
+			if len(admins) == 1 && admins[0] == userID {
+				return domain.ErrLastAdmin
+			}
+		}
+
+		res := tx.Where("user_id = ? AND role = ?", userID, string(role)).Delete(&UserRolePO{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return domain.ErrRoleNotAssigned
+		}
+		return nil
+	})
+}
+
 // 确保 UserRepository 实现了 domain.UserRepository 接口
 var _ domain.UserRepository = (*UserRepository)(nil)
@@ -0,0 +1,31 @@
+package persistence
+
+import (
+	"time"
+
+	"mygo/internal/user/domain"
+)
+
+// UserRolePO 用户角色关联（Persistence Object）。
+// 映射到表 `user_roles`，(user_id, role) 唯一。
+type UserRolePO struct {
+	ID     int64  `gorm:"column:id;primaryKey"`
+	UserID int64  `gorm:"column:user_id;not null;uniqueIndex:idx_user_roles_user_role"`
+	Role   string `gorm:"column:role;type:varchar(32);not null;uniqueIndex:idx_user_roles_user_role"`
+
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (UserRolePO) TableName() string { return "user_roles" }
+
+// rolesToDomain 转换角色列表
+func rolesToDomain(pos []UserRolePO) []domain.Role {
+	if len(pos) == 0 {
+		return nil
+	}
+	roles := make([]domain.Role, 0, len(pos))
+	for _, p := range pos {
+		roles = append(roles, domain.Role(p.Role))
+	}
+	return roles
+}
@@ -0,0 +1,90 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"mygo/internal/user/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeleteMe 注销当前账号（需再次输入密码），宽限期内可由管理员恢复
+// DELETE /api/users/me
+func (h *Handler) DeleteMe(c *gin.Context) {
+	principal, _ := PrincipalFrom(c)
+
+	var req DeleteAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, http.StatusBadRequest, 400, "invalid request body")
+		return
+	}
+
+	user, err := h.userService.DeleteAccount(requestContext(c), principal.UserID, req.Password)
+	if err != nil {
+		failAccount(c, err)
+		return
+	}
+
+	// 会话已全部清除，一并删除浏览器中的会话 Cookie
+	if h.sessionFromCookie(c) != "" {
+		h.clearSessionCookies(c)
+	}
+	success(c, &DeleteAccountResponse{
+		DeletedAt:  *user.DeletedAt,
+		PurgeAfter: *user.PurgeAfter,
+	})
+}
+
+// ExportMe 导出当前用户的个人数据（ZIP）
+// GET /api/users/me/export
+func (h *Handler) ExportMe(c *gin.Context) {
+	principal, _ := PrincipalFrom(c)
+
+	data, err := h.userService.ExportUserData(requestContext(c), principal.UserID)
+	if err != nil {
+		failAccount(c, err)
+		return
+	}
+
+	filename := fmt.Sprintf("mygo-export-%d-%s.zip", principal.UserID, time.Now().UTC().Format("20060102"))
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "application/zip", data)
+}
+
+// RestoreUser 恢复宽限期内已注销的账号
+// POST /api/admin/users/:user_id/restore
+func (h *Handler) RestoreUser(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		fail(c, http.StatusBadRequest, 400, "invalid user id")
+		return
+	}
+
+	if err := h.userService.RestoreAccount(requestContext(c), userID); err != nil {
+		failAccount(c, err)
+		return
+	}
+
+	success(c, nil)
+}
+
+// failAccount 注销与导出相关错误映射
+func failAccount(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		fail(c, http.StatusNotFound, 404, "user not found")
+	case errors.Is(err, domain.ErrUserNotDeleted):
+		fail(c, http.StatusConflict, 409, "user is not pending deletion")
+	case errors.Is(err, domain.ErrIncorrectPassword):
+		fail(c, http.StatusBadRequest, 400, "password is incorrect")
+	case errors.Is(err, domain.ErrInvalidInput):
+		fail(c, http.StatusBadRequest, 400, "invalid input")
+	default:
+		fail(c, http.StatusInternalServerError, 500, "internal server error")
+	}
+}
@@ -0,0 +1,208 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"mygo/internal/user/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultUserPageSize 用户列表默认页大小
+const defaultUserPageSize = 50
+
+// ListUsers 按条件分页查询用户
+// GET /api/admin/users?username=&email=&created_from=&created_to=&verified=&role=&limit=&offset=
+func (h *Handler) ListUsers(c *gin.Context) {
+	filter, err := parseUserFilter(c)
+	if err != nil {
+		fail(c, http.StatusBadRequest, 400, err.Error())
+		return
+	}
+
+	users, total, err := h.userService.ListUsers(requestContext(c), filter)
+	if err != nil {
+		failAdminUser(c, err)
+		return
+	}
+
+	resp := &AdminUserListResponse{Items: make([]*AdminUserResponse, 0, len(users)), Total: total}
+	for _, u := range users {
+		resp.Items = append(resp.Items, newAdminUserResponse(u))
+	}
+	success(c, resp)
+}
+
+// GetUserDetail 查看用户及其当前会话
+// GET /api/admin/users/:user_id
+func (h *Handler) GetUserDetail(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		fail(c, http.StatusBadRequest, 400, "invalid user id")
+		return
+	}
+
+	user, sessions, err := h.userService.GetUserDetail(requestContext(c), userID)
+	if err != nil {
+		failAdminUser(c, err)
+		return
+	}
+
+	resp := &AdminUserDetailResponse{
+		AdminUserResponse: newAdminUserResponse(user),
+		Sessions:          make([]*SessionSummaryResponse, 0, len(sessions)),
+	}
+	for _, s := range sessions {
+		resp.Sessions = append(resp.Sessions, &SessionSummaryResponse{IDPrefix: s.IDPrefix, CreatedAt: s.CreatedAt})
+	}
+	success(c, resp)
+}
+
+// DisableUser 停用用户
+// POST /api/admin/users/:user_id/disable
+func (h *Handler) DisableUser(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		fail(c, http.StatusBadRequest, 400, "invalid user id")
+		return
+	}
+
+	// 请求体可省略
+	var req DisableUserRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			fail(c, http.StatusBadRequest, 400, "invalid request body")
+			return
+		}
+	}
+
+	if err := h.userService.DisableUser(requestContext(c), userID, req.Reason); err != nil {
+		failAdminUser(c, err)
+		return
+	}
+
+	success(c, nil)
+}
+
+// EnableUser 重新启用用户
+// POST /api/admin/users/:user_id/enable
+func (h *Handler) EnableUser(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		fail(c, http.StatusBadRequest, 400, "invalid user id")
+		return
+	}
+
+	if err := h.userService.EnableUser(requestContext(c), userID); err != nil {
+		failAdminUser(c, err)
+		return
+	}
+
+	success(c, nil)
+}
+
+// ForcePasswordReset 要求用户修改密码
+// POST /api/admin/users/:user_id/password-reset
+func (h *Handler) ForcePasswordReset(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		fail(c, http.StatusBadRequest, 400, "invalid user id")
+		return
+	}
+
+	if err := h.userService.ForcePasswordReset(requestContext(c), userID); err != nil {
+		failAdminUser(c, err)
+		return
+	}
+
+	success(c, nil)
+}
+
+// MarkEmailVerified 将用户邮箱标记为已验证
+// POST /api/admin/users/:user_id/verify-email
+func (h *Handler) MarkEmailVerified(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		fail(c, http.StatusBadRequest, 400, "invalid user id")
+		return
+	}
+
+	if err := h.userService.MarkEmailVerified(requestContext(c), userID); err != nil {
+		failAdminUser(c, err)
+		return
+	}
+
+	success(c, nil)
+}
+
+// parseUserFilter 解析用户查询参数
+func parseUserFilter(c *gin.Context) (domain.UserFilter, error) {
+	filter := domain.UserFilter{
+		UsernamePrefix: c.Query("username"),
+		EmailPrefix:    c.Query("email"),
+		Role:           domain.Role(c.Query("role")),
+	}
+
+	for _, p := range []struct {
+		name string
+		dst  **time.Time
+	}{{"created_from", &filter.CreatedFrom}, {"created_to", &filter.CreatedTo}} {
+		if v := c.Query(p.name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return filter, errors.New("invalid " + p.name + ", expected RFC 3339")
+			}
+			*p.dst = &t
+		}
+	}
+	if v := c.Query("verified"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return filter, errors.New("invalid verified")
+		}
+		filter.Verified = &b
+	}
+
+	limit, offset, err := parsePagination(c, defaultUserPageSize)
+	if err != nil {
+		return filter, err
+	}
+	filter.Limit, filter.Offset = limit, offset
+	return filter, nil
+}
+
+// failAdminUser 用户管理相关错误映射
+func failAdminUser(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		fail(c, http.StatusNotFound, 404, "user not found")
+	case errors.Is(err, domain.ErrInvalidRole):
+		fail(c, http.StatusBadRequest, 400, "invalid role")
+	case errors.Is(err, domain.ErrInvalidInput):
+		fail(c, http.StatusBadRequest, 400, "invalid input")
+	default:
+		fail(c, http.StatusInternalServerError, 500, "internal server error")
+	}
+}
+
+func newAdminUserResponse(u *domain.User) *AdminUserResponse {
+	resp := &AdminUserResponse{
+		UserID:             u.UserID,
+		Username:           u.Username,
+		Email:              u.Email,
+		Avatar:             u.Avatar,
+		Roles:              make([]string, 0, len(u.Roles)),
+		InvitedBy:          u.InvitedBy,
+		EmailVerifiedAt:    u.EmailVerifiedAt,
+		DisabledAt:         u.DisabledAt,
+		MustChangePassword: u.MustChangePassword,
+		CreatedAt:          u.CreatedAt,
+	}
+	for _, r := range u.Roles {
+		resp.Roles = append(resp.Roles, string(r))
+	}
+	return resp
+}
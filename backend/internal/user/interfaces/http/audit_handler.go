@@ -0,0 +1,128 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"mygo/internal/user/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultAuthEventPageSize = 50
+
+// MyActivity 当前用户的认证活动
+// GET /api/users/me/activity?limit=&offset=
+func (h *Handler) MyActivity(c *gin.Context) {
+	principal, _ := PrincipalFrom(c)
+
+	filter, err := parseAuthEventFilter(c)
+	if err != nil {
+		fail(c, http.StatusBadRequest, 400, err.Error())
+		return
+	}
+	filter.UserID = principal.UserID
+
+	h.listAuthEvents(c, filter)
+}
+
+// ListAuthEvents 查询认证审计事件（管理员）
+// GET /api/admin/auth-events?user_id=&type=&ip=&from=&to=&limit=&offset=
+func (h *Handler) ListAuthEvents(c *gin.Context) {
+	filter, err := parseAuthEventFilter(c)
+	if err != nil {
+		fail(c, http.StatusBadRequest, 400, err.Error())
+		return
+	}
+
+	if v := c.Query("user_id"); v != "" {
+		if filter.UserID, err = strconv.ParseInt(v, 10, 64); err != nil {
+			fail(c, http.StatusBadRequest, 400, "invalid user_id")
+			return
+		}
+	}
+	filter.IP = c.Query("ip")
+
+	h.listAuthEvents(c, filter)
+}
+
+func (h *Handler) listAuthEvents(c *gin.Context, filter domain.AuthEventFilter) {
+	events, total, err := h.userService.ListAuthEvents(requestContext(c), filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			fail(c, http.StatusBadRequest, 400, "invalid time range")
+		} else {
+			fail(c, http.StatusInternalServerError, 500, "internal server error")
+		}
+		return
+	}
+
+	resp := &AuthEventListResponse{Items: make([]*AuthEventResponse, 0, len(events)), Total: total}
+	for _, e := range events {
+		resp.Items = append(resp.Items, &AuthEventResponse{
+			ID:        e.ID,
+			UserID:    e.UserID,
+			Username:  e.Username,
+			ActorID:   e.ActorID,
+			Type:      string(e.Type),
+			Detail:    e.Detail,
+			IP:        e.IP,
+			UserAgent: e.UserAgent,
+			RequestID: e.RequestID,
+			CreatedAt: e.CreatedAt,
+		})
+	}
+	success(c, resp)
+}
+
+// parseAuthEventFilter 解析通用查询参数：type（逗号分隔）、from/to（RFC 3339）、limit、offset
+func parseAuthEventFilter(c *gin.Context) (domain.AuthEventFilter, error) {
+	filter := domain.AuthEventFilter{Limit: defaultAuthEventPageSize}
+
+	if v := c.Query("type"); v != "" {
+		for _, t := range strings.Split(v, ",") {
+			filter.Types = append(filter.Types, domain.AuthEventType(strings.TrimSpace(t)))
+		}
+	}
+	for _, p := range []struct {
+		name string
+		dst  **time.Time
+	}{{"from", &filter.From}, {"to", &filter.To}} {
+		if v := c.Query(p.name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return filter, errors.New("invalid " + p.name + ", expected RFC 3339")
+			}
+			*p.dst = &t
+		}
+	}
+	limit, offset, err := parsePagination(c, defaultAuthEventPageSize)
+	if err != nil {
+		return filter, err
+	}
+	filter.Limit, filter.Offset = limit, offset
+	return filter, nil
+}
+
+// parsePagination 解析 limit、offset 查询参数
+func parsePagination(c *gin.Context, defaultLimit int) (limit, offset int, err error) {
+	limit = defaultLimit
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return 0, 0, errors.New("invalid limit")
+		}
+		limit = n
+	}
+	if v := c.Query("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return 0, 0, errors.New("invalid offset")
+		}
+		offset = n
+	}
+	return limit, offset, nil
+}
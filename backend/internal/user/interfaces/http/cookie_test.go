@@ -0,0 +1,51 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestValidCSRF(t *testing.T) {
+	h := &Handler{cookie: &CookieConfig{CSRFSecret: []byte("test-secret")}}
+	other := &Handler{cookie: &CookieConfig{CSRFSecret: []byte("other-secret")}}
+	token := h.csrfTokenFor("session-a")
+
+	tests := []struct {
+		name   string
+		method string
+		header string
+		want   bool
+	}{
+		{"safe method without token", http.MethodGet, "", true},
+		{"head without token", http.MethodHead, "", true},
+		{"post without token", http.MethodPost, "", false},
+		{"post with token", http.MethodPost, token, true},
+		{"delete with token", http.MethodDelete, token, true},
+		{"token of another session", http.MethodPost, h.csrfTokenFor("session-b"), false},
+		{"token signed with another secret", http.MethodPost, other.csrfTokenFor("session-a"), false},
+		{"truncated token", http.MethodPatch, token[:len(token)-1], false},
+		{"raw session id", http.MethodPut, "session-a", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(tt.method, "/", nil)
+			if tt.header != "" {
+				c.Request.Header.Set(CSRFHeader, tt.header)
+			}
+			if got := h.validCSRF(c, "session-a"); got != tt.want {
+				t.Errorf("validCSRF() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCSRFTokenForIsDeterministic(t *testing.T) {
+	h := &Handler{cookie: &CookieConfig{CSRFSecret: []byte("test-secret")}}
+	if a, b := h.csrfTokenFor("s"), h.csrfTokenFor("s"); a != b || len(a) != 64 {
+		t.Fatalf("csrfTokenFor() = %q, %q, want identical 64-char hex tokens", a, b)
+	}
+}
@@ -1,10 +1,19 @@
 package http
 
+import "time"
+
 // RegisterRequest 用户注册请求
 type RegisterRequest struct {
 	Username string `json:"username"`
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	// InviteCode 邀请码，invite 注册模式下必填
+	InviteCode string `json:"invite_code"`
+}
+
+// RegistrationInfoResponse 注册模式响应，供前端决定是否展示邀请码输入框
+type RegistrationInfoResponse struct {
+	Mode string `json:"mode"`
 }
 
 // RegisterResponse 用户注册响应
@@ -18,13 +27,42 @@ type RegisterResponse struct {
 type LoginRequest struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
+	// Mode 会话传递方式：header（默认，返回 session_id）或 cookie（写入 HttpOnly Cookie）
+	Mode string `json:"mode"`
 }
 
 // LoginResponse 用户登录响应
 type LoginResponse struct {
-	SessionID string `json:"session_id"`
+	// SessionID 仅 header 模式返回
+	SessionID string `json:"session_id,omitempty"`
+	// CSRFToken 仅 cookie 模式返回，需在非安全方法请求中通过 X-CSRF-Token 头回传
+	CSRFToken string `json:"csrf_token,omitempty"`
 	UserID    int64  `json:"user_id"`
 	Username  string `json:"username"`
+	// MustChangePassword 为 true 时前端应引导用户修改密码，其他接口返回 403
+	MustChangePassword bool `json:"must_change_password,omitempty"`
+}
+
+// TokenRequest JWT 令牌签发请求
+type TokenRequest struct {
+	GrantType    string `json:"grant_type"` // password 或 refresh_token
+	Username     string `json:"username"`
+	Password     string `json:"password"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// TokenPairResponse JWT 令牌对响应
+type TokenPairResponse struct {
+	TokenType        string    `json:"token_type"`
+	AccessToken      string    `json:"access_token"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+// RevokeRefreshTokenRequest 作废刷新令牌请求
+type RevokeRefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
 }
 
 // UserResponse 用户信息响应
@@ -35,3 +73,150 @@ type UserResponse struct {
 	Email    string `json:"email"`
 	Avatar   string `json:"avatar,omitempty"`
 }
+
+// GrantRoleRequest 授予角色请求
+type GrantRoleRequest struct {
+	Role string `json:"role"`
+}
+
+// RolesResponse 用户角色响应
+type RolesResponse struct {
+	UserID int64    `json:"user_id"`
+	Roles  []string `json:"roles"`
+}
+
+// CreateTokenRequest 创建个人访问令牌请求
+type CreateTokenRequest struct {
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes"`
+	ExpiresInDays int      `json:"expires_in_days"`
+}
+
+// TokenResponse 个人访问令牌响应，Token 仅在创建时返回
+type TokenResponse struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	Token      string     `json:"token,omitempty"`
+}
+
+// ProfileResponse 当前用户资料响应
+type ProfileResponse struct {
+	UserID   int64    `json:"user_id"`
+	Username string   `json:"username"`
+	Email    string   `json:"email"`
+	Avatar   string   `json:"avatar,omitempty"`
+	Roles    []string `json:"roles"`
+}
+
+// UpdateProfileRequest 更新资料请求，省略的字段不修改
+type UpdateProfileRequest struct {
+	Username *string `json:"username"`
+	Email    *string `json:"email"`
+}
+
+// ChangePasswordRequest 修改密码请求
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+// DeleteAccountRequest 注销账号请求
+type DeleteAccountRequest struct {
+	Password string `json:"password"`
+}
+
+// DeleteAccountResponse 注销账号响应
+type DeleteAccountResponse struct {
+	DeletedAt  time.Time `json:"deleted_at"`
+	PurgeAfter time.Time `json:"purge_after"`
+}
+
+// AuthEventResponse 认证审计事件响应
+type AuthEventResponse struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	Username  string    `json:"username,omitempty"`
+	ActorID   int64     `json:"actor_id,omitempty"`
+	Type      string    `json:"type"`
+	Detail    string    `json:"detail,omitempty"`
+	IP        string    `json:"ip,omitempty"`
+	UserAgent string    `json:"user_agent,omitempty"`
+	RequestID string    `json:"request_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AuthEventListResponse 审计事件分页响应
+type AuthEventListResponse struct {
+	Items []*AuthEventResponse `json:"items"`
+	Total int64                `json:"total"`
+}
+
+// CreateInvitationRequest 创建邀请请求
+type CreateInvitationRequest struct {
+	Note          string `json:"note"`
+	MaxUses       int    `json:"max_uses"`
+	ExpiresInDays int    `json:"expires_in_days"`
+}
+
+// InvitationResponse 邀请响应，Code 仅在创建时返回
+type InvitationResponse struct {
+	ID        int64      `json:"id"`
+	Code      string     `json:"code,omitempty"`
+	Prefix    string     `json:"prefix"`
+	CreatedBy int64      `json:"created_by"`
+	Note      string     `json:"note,omitempty"`
+	MaxUses   int        `json:"max_uses"`
+	UsedCount int        `json:"used_count"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// InvitationListResponse 邀请分页响应
+type InvitationListResponse struct {
+	Items []*InvitationResponse `json:"items"`
+	Total int64                 `json:"total"`
+}
+
+// DisableUserRequest 停用用户请求
+type DisableUserRequest struct {
+	Reason string `json:"reason"`
+}
+
+// AdminUserResponse 管理员视角的用户信息
+type AdminUserResponse struct {
+	UserID             int64      `json:"user_id"`
+	Username           string     `json:"username"`
+	Email              string     `json:"email"`
+	Avatar             string     `json:"avatar,omitempty"`
+	Roles              []string   `json:"roles"`
+	InvitedBy          int64      `json:"invited_by,omitempty"`
+	EmailVerifiedAt    *time.Time `json:"email_verified_at,omitempty"`
+	DisabledAt         *time.Time `json:"disabled_at,omitempty"`
+	MustChangePassword bool       `json:"must_change_password"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
+
+// AdminUserListResponse 用户分页响应
+type AdminUserListResponse struct {
+	Items []*AdminUserResponse `json:"items"`
+	Total int64                `json:"total"`
+}
+
+// SessionSummaryResponse 会话概要
+type SessionSummaryResponse struct {
+	IDPrefix  string    `json:"id_prefix"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AdminUserDetailResponse 用户详情（含会话）
+type AdminUserDetailResponse struct {
+	*AdminUserResponse
+	Sessions []*SessionSummaryResponse `json:"sessions"`
+}
@@ -1,10 +1,13 @@
 package http
 
 import (
+	"context"
 	"errors"
+	"math"
 	"net/http"
 	"strconv"
 
+	"mygo/internal/requestid"
 	"mygo/internal/user/domain"
 
 	"github.com/gin-gonic/gin"
@@ -13,18 +16,35 @@ import (
 // Handler 用户 HTTP 处理器
 type Handler struct {
 	userService domain.UserService
+
+	// cookie 非空时启用 Cookie 会话模式
+	cookie *CookieConfig
 }
 
 // NewHandler 构造函数
-func NewHandler(userService domain.UserService) *Handler {
-	return &Handler{userService: userService}
+func NewHandler(userService domain.UserService, opts ...HandlerOption) *Handler {
+	h := &Handler{userService: userService}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 // Response 统一响应格式
 type Response struct {
-	Code    int         `json:"code"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
+	Code    int               `json:"code"`
+	Message string            `json:"message"`
+	Data    interface{}       `json:"data,omitempty"`
+	Errors  []FieldErrorEntry `json:"errors,omitempty"`
+	// RequestID 仅错误响应返回，便于用户反馈问题时定位日志
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// FieldErrorEntry 字段级错误
+type FieldErrorEntry struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
 }
 
 // 响应辅助函数
@@ -37,12 +57,54 @@ func success(c *gin.Context, data interface{}) {
 }
 
 func fail(c *gin.Context, httpCode int, code int, message string) {
-	c.JSON(httpCode, Response{
+	failWith(c, httpCode, Response{
 		Code:    code,
 		Message: message,
 	})
 }
 
+// failWith 输出错误响应并附带请求 ID
+func failWith(c *gin.Context, httpCode int, resp Response) {
+	resp.RequestID = requestid.FromContext(c.Request.Context())
+	c.JSON(httpCode, resp)
+}
+
+// failValidation 返回字段级校验错误
+func failValidation(c *gin.Context, verr *domain.ValidationError) {
+	entries := make([]FieldErrorEntry, 0, len(verr.Fields))
+	for _, f := range verr.Fields {
+		entries = append(entries, FieldErrorEntry{Field: f.Field, Code: f.Code, Message: f.Message})
+	}
+	failWith(c, http.StatusBadRequest, Response{
+		Code:    400,
+		Message: "validation failed",
+		Errors:  entries,
+	})
+}
+
+// failConflict 返回 409，能确定冲突字段时附带字段级错误
+func failConflict(c *gin.Context, cerr *domain.ConflictError) {
+	resp := Response{Code: 409, Message: "username or email already taken"}
+	if cerr.Field != "" {
+		resp.Message = cerr.Field + " already taken"
+		resp.Errors = []FieldErrorEntry{{Field: cerr.Field, Code: "taken", Message: "is already taken"}}
+	}
+	failWith(c, http.StatusConflict, resp)
+}
+
+// requestContext 返回注入了请求来源信息的 context
+func requestContext(c *gin.Context) context.Context {
+	meta := domain.RequestMeta{
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		RequestID: requestid.FromContext(c.Request.Context()),
+	}
+	if principal, ok := PrincipalFrom(c); ok {
+		meta.ActorID = principal.UserID
+	}
+	return domain.WithRequestMeta(c.Request.Context(), meta)
+}
+
 // Register 用户注册
 // POST /api/users/register
 func (h *Handler) Register(c *gin.Context) {
@@ -52,9 +114,22 @@ func (h *Handler) Register(c *gin.Context) {
 		return
 	}
 
-	user, err := h.userService.Register(c.Request.Context(), req.Username, req.Email, req.Password)
+	user, err := h.userService.Register(requestContext(c), domain.RegisterCommand{
+		Username:   req.Username,
+		Email:      req.Email,
+		Password:   req.Password,
+		InviteCode: req.InviteCode,
+	})
 	if err != nil {
+		var verr *domain.ValidationError
+		var cerr *domain.ConflictError
 		switch {
+		case errors.As(err, &verr):
+			failValidation(c, verr)
+		case errors.Is(err, domain.ErrRegistrationClosed):
+			fail(c, http.StatusForbidden, 403, "registration is closed")
+		case errors.As(err, &cerr):
+			failConflict(c, cerr)
 		case errors.Is(err, domain.ErrUserAlreadyExists):
 			fail(c, http.StatusConflict, 409, "user already exists")
 		case errors.Is(err, domain.ErrInvalidInput):
@@ -81,12 +156,29 @@ func (h *Handler) Login(c *gin.Context) {
 		fail(c, http.StatusBadRequest, 400, "invalid request body")
 		return
 	}
+	switch req.Mode {
+	case "", "header":
+	case "cookie":
+		if h.cookie == nil {
+			fail(c, http.StatusBadRequest, 400, "cookie sessions are not enabled")
+			return
+		}
+	default:
+		fail(c, http.StatusBadRequest, 400, "invalid login mode")
+		return
+	}
 
-	sessionID, user, err := h.userService.Login(c.Request.Context(), req.Username, req.Password)
+	sessionID, user, err := h.userService.Login(requestContext(c), req.Username, req.Password)
 	if err != nil {
+		var lockout *domain.LockoutError
 		switch {
+		case errors.As(err, &lockout):
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(lockout.RetryAfter.Seconds()))))
+			fail(c, http.StatusTooManyRequests, 429, "too many failed login attempts, try again later")
 		case errors.Is(err, domain.ErrInvalidCredentials):
 			fail(c, http.StatusUnauthorized, 401, "invalid username or password")
+		case errors.Is(err, domain.ErrUserDisabled):
+			fail(c, http.StatusForbidden, 403, "account is disabled")
 		case errors.Is(err, domain.ErrInvalidInput):
 			fail(c, http.StatusBadRequest, 400, "invalid input")
 		default:
@@ -96,27 +188,45 @@ func (h *Handler) Login(c *gin.Context) {
 	}
 
 	resp := &LoginResponse{
-		SessionID: sessionID,
-		UserID:    user.UserID,
-		Username:  user.Username,
+		UserID:             user.UserID,
+		Username:           user.Username,
+		MustChangePassword: user.MustChangePassword,
+	}
+	if req.Mode == "cookie" {
+		// 会话 ID 只放在 HttpOnly Cookie 中，不返回给前端脚本
+		resp.CSRFToken = h.setSessionCookies(c, sessionID)
+	} else {
+		resp.SessionID = sessionID
 	}
 	success(c, resp)
 }
 
-// Logout 用户登出
+// Logout 用户登出，支持 X-Session-ID 头或会话 Cookie
 // POST /api/users/logout
 func (h *Handler) Logout(c *gin.Context) {
 	sessionID := c.GetHeader("X-Session-ID")
+	viaCookie := false
+	if sessionID == "" {
+		sessionID = h.sessionFromCookie(c)
+		viaCookie = sessionID != ""
+	}
 	if sessionID == "" {
 		fail(c, http.StatusBadRequest, 400, "session id required")
 		return
 	}
+	if viaCookie && !h.validCSRF(c, sessionID) {
+		fail(c, http.StatusForbidden, 403, "invalid csrf token")
+		return
+	}
 
-	if err := h.userService.Logout(c.Request.Context(), sessionID); err != nil {
+	if err := h.userService.Logout(requestContext(c), sessionID); err != nil {
 		fail(c, http.StatusInternalServerError, 500, "internal server error")
 		return
 	}
 
+	if viaCookie {
+		h.clearSessionCookies(c)
+	}
 	success(c, nil)
 }
 
@@ -130,7 +240,7 @@ func (h *Handler) GetUser(c *gin.Context) {
 		return
 	}
 
-	user, err := h.userService.GetUserByID(c.Request.Context(), id)
+	user, err := h.userService.GetUserByID(requestContext(c), id)
 	if err != nil {
 		if errors.Is(err, domain.ErrUserNotFound) {
 			fail(c, http.StatusNotFound, 404, "user not found")
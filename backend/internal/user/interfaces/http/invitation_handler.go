@@ -0,0 +1,112 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"mygo/internal/user/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultInvitationPageSize 邀请列表默认页大小
+const defaultInvitationPageSize = 50
+
+// RegistrationInfo 获取当前注册模式
+// GET /api/users/registration
+func (h *Handler) RegistrationInfo(c *gin.Context) {
+	success(c, &RegistrationInfoResponse{Mode: string(h.userService.RegistrationMode())})
+}
+
+// CreateInvitation 创建注册邀请
+// POST /api/admin/invitations
+func (h *Handler) CreateInvitation(c *gin.Context) {
+	principal, _ := PrincipalFrom(c)
+
+	var req CreateInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, http.StatusBadRequest, 400, "invalid request body")
+		return
+	}
+
+	inv, code, err := h.userService.CreateInvitation(requestContext(c), principal.UserID, domain.CreateInvitationCommand{
+		Note:      req.Note,
+		MaxUses:   req.MaxUses,
+		ExpiresIn: time.Duration(req.ExpiresInDays) * 24 * time.Hour,
+	})
+	if err != nil {
+		failInvitation(c, err)
+		return
+	}
+
+	resp := newInvitationResponse(inv)
+	resp.Code = code
+	success(c, resp)
+}
+
+// ListInvitations 分页列出邀请
+// GET /api/admin/invitations?limit=&offset=
+func (h *Handler) ListInvitations(c *gin.Context) {
+	limit, offset, err := parsePagination(c, defaultInvitationPageSize)
+	if err != nil {
+		fail(c, http.StatusBadRequest, 400, err.Error())
+		return
+	}
+
+	invitations, total, err := h.userService.ListInvitations(requestContext(c), limit, offset)
+	if err != nil {
+		failInvitation(c, err)
+		return
+	}
+
+	resp := &InvitationListResponse{Items: make([]*InvitationResponse, 0, len(invitations)), Total: total}
+	for _, inv := range invitations {
+		resp.Items = append(resp.Items, newInvitationResponse(inv))
+	}
+	success(c, resp)
+}
+
+// RevokeInvitation 撤销邀请
+// DELETE /api/admin/invitations/:invitation_id
+func (h *Handler) RevokeInvitation(c *gin.Context) {
+	invitationID, err := strconv.ParseInt(c.Param("invitation_id"), 10, 64)
+	if err != nil {
+		fail(c, http.StatusBadRequest, 400, "invalid invitation id")
+		return
+	}
+
+	if err := h.userService.RevokeInvitation(requestContext(c), invitationID); err != nil {
+		failInvitation(c, err)
+		return
+	}
+
+	success(c, nil)
+}
+
+// failInvitation 邀请相关错误映射
+func failInvitation(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvitationNotFound):
+		fail(c, http.StatusNotFound, 404, "invitation not found")
+	case errors.Is(err, domain.ErrInvalidInput):
+		fail(c, http.StatusBadRequest, 400, "invalid input")
+	default:
+		fail(c, http.StatusInternalServerError, 500, "internal server error")
+	}
+}
+
+func newInvitationResponse(inv *domain.Invitation) *InvitationResponse {
+	return &InvitationResponse{
+		ID:        inv.ID,
+		Prefix:    inv.Prefix,
+		CreatedBy: inv.CreatedBy,
+		Note:      inv.Note,
+		MaxUses:   inv.MaxUses,
+		UsedCount: inv.UsedCount,
+		ExpiresAt: inv.ExpiresAt,
+		RevokedAt: inv.RevokedAt,
+		CreatedAt: inv.CreatedAt,
+	}
+}
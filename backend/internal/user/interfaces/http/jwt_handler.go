@@ -0,0 +1,105 @@
+package http
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"mygo/internal/user/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IssueToken 签发 JWT 令牌对
+// POST /api/users/token
+//
+//	grant_type=password：username + password
+//	grant_type=refresh_token：refresh_token（旧刷新令牌随即失效）
+func (h *Handler) IssueToken(c *gin.Context) {
+	var req TokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, http.StatusBadRequest, 400, "invalid request body")
+		return
+	}
+
+	var (
+		pair *domain.TokenPair
+		err  error
+	)
+	switch req.GrantType {
+	case "password":
+		pair, _, err = h.userService.IssueTokenPair(requestContext(c), req.Username, req.Password)
+	case "refresh_token":
+		pair, err = h.userService.RefreshTokenPair(requestContext(c), req.RefreshToken)
+	default:
+		fail(c, http.StatusBadRequest, 400, "unsupported grant_type")
+		return
+	}
+	if err != nil {
+		failJWT(c, err)
+		return
+	}
+
+	// 令牌响应不应被缓存
+	c.Header("Cache-Control", "no-store")
+	success(c, &TokenPairResponse{
+		TokenType:        "Bearer",
+		AccessToken:      pair.AccessToken,
+		ExpiresAt:        pair.AccessExpiresAt,
+		RefreshToken:     pair.RefreshToken,
+		RefreshExpiresAt: pair.RefreshExpiresAt,
+	})
+}
+
+// RevokeRefreshToken 作废刷新令牌（及同一家族的后续令牌）
+// POST /api/users/token/revoke
+func (h *Handler) RevokeRefreshToken(c *gin.Context) {
+	var req RevokeRefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.RefreshToken == "" {
+		fail(c, http.StatusBadRequest, 400, "invalid request body")
+		return
+	}
+
+	if err := h.userService.RevokeRefreshToken(requestContext(c), req.RefreshToken); err != nil {
+		fail(c, http.StatusInternalServerError, 500, "internal server error")
+		return
+	}
+
+	success(c, nil)
+}
+
+// JWKS 发布验签公钥，供其他服务校验访问令牌
+// GET /.well-known/jwks.json
+func (h *Handler) JWKS(c *gin.Context) {
+	data, err := h.userService.JWKS()
+	if err != nil {
+		fail(c, http.StatusNotFound, 404, "jwks not available")
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.Data(http.StatusOK, "application/json", data)
+}
+
+// failJWT 令牌签发相关错误映射
+func failJWT(c *gin.Context, err error) {
+	var lockout *domain.LockoutError
+	switch {
+	case errors.As(err, &lockout):
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(lockout.RetryAfter.Seconds()))))
+		fail(c, http.StatusTooManyRequests, 429, "too many failed login attempts, try again later")
+	case errors.Is(err, domain.ErrInvalidCredentials):
+		fail(c, http.StatusUnauthorized, 401, "invalid username or password")
+	case errors.Is(err, domain.ErrUserDisabled):
+		fail(c, http.StatusForbidden, 403, "account is disabled")
+	case errors.Is(err, domain.ErrPasswordChangeRequired):
+		fail(c, http.StatusForbidden, 403, "password change required")
+	case errors.Is(err, domain.ErrInvalidRefreshToken), errors.Is(err, domain.ErrRefreshTokenReused):
+		fail(c, http.StatusUnauthorized, 401, "invalid refresh token")
+	case errors.Is(err, domain.ErrInvalidInput):
+		fail(c, http.StatusBadRequest, 400, "invalid input")
+	default:
+		fail(c, http.StatusInternalServerError, 500, "internal server error")
+	}
+}
@@ -0,0 +1,140 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"mygo/internal/logging"
+	"mygo/internal/user/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// principalKey gin.Context 中保存访问主体的 key
+const principalKey = "user.principal"
+
+// RequireAuth 认证中间件：依次校验 Authorization: Bearer（个人访问令牌或 JWT 访问令牌）、
+// X-Session-ID 会话头、会话 Cookie，并注入访问主体。
+// 通过 Cookie 认证的非安全方法请求还需携带有效的 X-CSRF-Token。
+func (h *Handler) RequireAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authenticate, credential, viaCookie := h.credentialFrom(c)
+		if credential == "" {
+			fail(c, http.StatusUnauthorized, 401, "unauthorized")
+			c.Abort()
+			return
+		}
+		if viaCookie && !h.validCSRF(c, credential) {
+			fail(c, http.StatusForbidden, 403, "invalid csrf token")
+			c.Abort()
+			return
+		}
+
+		principal, err := authenticate(c.Request.Context(), credential)
+		if err != nil {
+			if errors.Is(err, domain.ErrUnauthenticated) {
+				fail(c, http.StatusUnauthorized, 401, "unauthorized")
+			} else {
+				fail(c, http.StatusInternalServerError, 500, "internal server error")
+			}
+			c.Abort()
+			return
+		}
+
+		if principal.MustChangePassword && !allowedBeforePasswordChange(c) {
+			fail(c, http.StatusForbidden, 403, "password change required")
+			c.Abort()
+			return
+		}
+
+		c.Set(principalKey, principal)
+		// 之后的服务与仓储日志带上 user_id
+		c.Request = c.Request.WithContext(logging.With(c.Request.Context(), "user_id", principal.UserID))
+		c.Next()
+	}
+}
+
+// RequirePermission 授权中间件：要求访问主体拥有指定权限。
+// 必须在 RequireAuth 之后使用。
+func (h *Handler) RequirePermission(perm domain.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := PrincipalFrom(c)
+		if !ok {
+			fail(c, http.StatusUnauthorized, 401, "unauthorized")
+			c.Abort()
+			return
+		}
+		if !principal.HasPermission(perm) {
+			fail(c, http.StatusForbidden, 403, "forbidden")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireSession 要求通过会话或 JWT 访问令牌认证，拒绝个人访问令牌。
+// 用于修改账号身份、密码、注销、导出数据与管理令牌等接口：令牌的 scope 不能覆盖这些操作，
+// 泄露的令牌不应能接管账号。必须在 RequireAuth 之后使用。
+func (h *Handler) RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := PrincipalFrom(c)
+		if !ok {
+			fail(c, http.StatusUnauthorized, 401, "unauthorized")
+			c.Abort()
+			return
+		}
+		if principal.TokenID != 0 {
+			fail(c, http.StatusForbidden, 403, "not allowed with a personal access token")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// credentialFrom 选择请求携带的凭证及对应的认证方法，viaCookie 表示凭证来自会话 Cookie
+func (h *Handler) credentialFrom(c *gin.Context) (authenticate func(context.Context, string) (*domain.Principal, error), credential string, viaCookie bool) {
+	if auth := c.GetHeader("Authorization"); auth != "" {
+		scheme, token, ok := strings.Cut(auth, " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") {
+			return nil, "", false
+		}
+		token = strings.TrimSpace(token)
+		if strings.HasPrefix(token, domain.TokenPrefix) {
+			return h.userService.AuthenticateToken, token, false
+		}
+		return h.userService.AuthenticateAccessToken, token, false
+	}
+	if sessionID := c.GetHeader("X-Session-ID"); sessionID != "" {
+		return h.userService.Authenticate, sessionID, false
+	}
+	if sessionID := h.sessionFromCookie(c); sessionID != "" {
+		return h.userService.Authenticate, sessionID, true
+	}
+	return nil, "", false
+}
+
+// allowedBeforePasswordChange 被要求重置密码的用户在修改密码前只能查看资料和修改密码
+func allowedBeforePasswordChange(c *gin.Context) bool {
+	path := c.FullPath()
+	switch {
+	case c.Request.Method == http.MethodGet && strings.HasSuffix(path, "/users/me"):
+		return true
+	case c.Request.Method == http.MethodPost && strings.HasSuffix(path, "/users/me/password"):
+		return true
+	}
+	return false
+}
+
+// PrincipalFrom 从 gin.Context 获取当前访问主体
+func PrincipalFrom(c *gin.Context) (*domain.Principal, bool) {
+	v, ok := c.Get(principalKey)
+	if !ok {
+		return nil, false
+	}
+	principal, ok := v.(*domain.Principal)
+	return principal, ok && principal != nil
+}
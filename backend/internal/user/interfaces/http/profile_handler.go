@@ -0,0 +1,147 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"mygo/internal/user/application"
+	"mygo/internal/user/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetMe 获取当前用户资料
+// GET /api/users/me
+func (h *Handler) GetMe(c *gin.Context) {
+	principal, _ := PrincipalFrom(c)
+
+	user, err := h.userService.GetProfile(requestContext(c), principal.UserID)
+	if err != nil {
+		failProfile(c, err)
+		return
+	}
+
+	success(c, newProfileResponse(user))
+}
+
+// UpdateMe 更新当前用户资料
+// PATCH /api/users/me
+func (h *Handler) UpdateMe(c *gin.Context) {
+	principal, _ := PrincipalFrom(c)
+
+	var req UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, http.StatusBadRequest, 400, "invalid request body")
+		return
+	}
+
+	user, err := h.userService.UpdateProfile(requestContext(c), principal.UserID, domain.UpdateProfileCommand{
+		Username: req.Username,
+		Email:    req.Email,
+	})
+	if err != nil {
+		failProfile(c, err)
+		return
+	}
+
+	success(c, newProfileResponse(user))
+}
+
+// ChangePassword 修改密码，撤销当前会话以外的全部会话
+// POST /api/users/me/password
+func (h *Handler) ChangePassword(c *gin.Context) {
+	principal, _ := PrincipalFrom(c)
+
+	var req ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, http.StatusBadRequest, 400, "invalid request body")
+		return
+	}
+
+	err := h.userService.ChangePassword(requestContext(c), principal.UserID, domain.ChangePasswordCommand{
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
+		KeepSessionID:   principal.SessionID,
+	})
+	if err != nil {
+		failProfile(c, err)
+		return
+	}
+
+	success(c, nil)
+}
+
+// UploadAvatar 上传头像（multipart 字段 avatar）
+// POST /api/users/me/avatar
+func (h *Handler) UploadAvatar(c *gin.Context) {
+	principal, _ := PrincipalFrom(c)
+
+	// 预留 multipart 边界等开销
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, application.MaxAvatarBytes+64<<10)
+
+	fileHeader, err := c.FormFile("avatar")
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			fail(c, http.StatusRequestEntityTooLarge, 413, "avatar image too large")
+			return
+		}
+		fail(c, http.StatusBadRequest, 400, "avatar file required")
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		fail(c, http.StatusBadRequest, 400, "avatar file required")
+		return
+	}
+	defer file.Close()
+
+	user, err := h.userService.UploadAvatar(requestContext(c), principal.UserID, file)
+	if err != nil {
+		failProfile(c, err)
+		return
+	}
+
+	success(c, newProfileResponse(user))
+}
+
+// failProfile 资料相关错误映射
+func failProfile(c *gin.Context, err error) {
+	var verr *domain.ValidationError
+	var cerr *domain.ConflictError
+	switch {
+	case errors.As(err, &verr):
+		failValidation(c, verr)
+	case errors.Is(err, domain.ErrUserNotFound):
+		fail(c, http.StatusNotFound, 404, "user not found")
+	case errors.As(err, &cerr):
+		failConflict(c, cerr)
+	case errors.Is(err, domain.ErrUserAlreadyExists):
+		fail(c, http.StatusConflict, 409, "username or email already taken")
+	case errors.Is(err, domain.ErrIncorrectPassword):
+		fail(c, http.StatusBadRequest, 400, "current password is incorrect")
+	case errors.Is(err, domain.ErrAvatarTooLarge):
+		fail(c, http.StatusRequestEntityTooLarge, 413, "avatar image too large")
+	case errors.Is(err, domain.ErrInvalidAvatar):
+		fail(c, http.StatusUnsupportedMediaType, 415, "avatar must be a png, jpeg, gif or webp image")
+	case errors.Is(err, domain.ErrInvalidInput):
+		fail(c, http.StatusBadRequest, 400, "invalid input")
+	default:
+		fail(c, http.StatusInternalServerError, 500, "internal server error")
+	}
+}
+
+func newProfileResponse(u *domain.User) *ProfileResponse {
+	resp := &ProfileResponse{
+		UserID:   u.UserID,
+		Username: u.Username,
+		Email:    u.Email,
+		Avatar:   u.Avatar,
+		Roles:    make([]string, 0, len(u.Roles)),
+	}
+	for _, r := range u.Roles {
+		resp.Roles = append(resp.Roles, string(r))
+	}
+	return resp
+}
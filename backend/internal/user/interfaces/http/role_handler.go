@@ -0,0 +1,112 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"mygo/internal/user/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListRoles 获取用户角色
+// GET /api/admin/users/:user_id/roles
+func (h *Handler) ListRoles(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		fail(c, http.StatusBadRequest, 400, "invalid user id")
+		return
+	}
+
+	roles, err := h.userService.ListRoles(requestContext(c), userID)
+	if err != nil {
+		failRole(c, err)
+		return
+	}
+
+	success(c, newRolesResponse(userID, roles))
+}
+
+// GrantRole 授予角色
+// POST /api/admin/users/:user_id/roles
+func (h *Handler) GrantRole(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		fail(c, http.StatusBadRequest, 400, "invalid user id")
+		return
+	}
+
+	var req GrantRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, http.StatusBadRequest, 400, "invalid request body")
+		return
+	}
+
+	if err := h.userService.GrantRole(requestContext(c), userID, domain.Role(req.Role)); err != nil {
+		failRole(c, err)
+		return
+	}
+
+	success(c, nil)
+}
+
+// RevokeRole 撤销角色
+// DELETE /api/admin/users/:user_id/roles/:role
+func (h *Handler) RevokeRole(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		fail(c, http.StatusBadRequest, 400, "invalid user id")
+		return
+	}
+
+	if err := h.userService.RevokeRole(requestContext(c), userID, domain.Role(c.Param("role"))); err != nil {
+		failRole(c, err)
+		return
+	}
+
+	success(c, nil)
+}
+
+// UnlockUser 解除用户登录锁定
+// POST /api/admin/users/:user_id/unlock
+func (h *Handler) UnlockUser(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		fail(c, http.StatusBadRequest, 400, "invalid user id")
+		return
+	}
+
+	if err := h.userService.UnlockUser(requestContext(c), userID); err != nil {
+		failRole(c, err)
+		return
+	}
+
+	success(c, nil)
+}
+
+// failRole 角色相关错误映射
+func failRole(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		fail(c, http.StatusNotFound, 404, "user not found")
+	case errors.Is(err, domain.ErrRoleNotAssigned):
+		fail(c, http.StatusNotFound, 404, "role not assigned")
+	case errors.Is(err, domain.ErrInvalidRole):
+		fail(c, http.StatusBadRequest, 400, "invalid role")
+	case errors.Is(err, domain.ErrInvalidInput):
+		fail(c, http.StatusBadRequest, 400, "invalid input")
+	case errors.Is(err, domain.ErrLastAdmin), errors.Is(err, domain.ErrRevokeOwnAdmin):
+		fail(c, http.StatusConflict, 409, err.Error())
+	default:
+		fail(c, http.StatusInternalServerError, 500, "internal server error")
+	}
+}
+
+func newRolesResponse(userID int64, roles []domain.Role) *RolesResponse {
+	resp := &RolesResponse{UserID: userID, Roles: make([]string, 0, len(roles))}
+	for _, r := range roles {
+		resp.Roles = append(resp.Roles, string(r))
+	}
+	return resp
+}
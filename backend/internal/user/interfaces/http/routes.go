@@ -1,14 +1,63 @@
 package http
 
-import "github.com/gin-gonic/gin"
+import (
+	"mygo/internal/user/domain"
+
+	"github.com/gin-gonic/gin"
+)
 
 // RegisterRoutes 注册用户相关路由
 func RegisterRoutes(r *gin.RouterGroup, h *Handler) {
 	users := r.Group("/users")
 	{
+		users.GET("/registration", h.RegistrationInfo)
 		users.POST("/register", h.Register)
 		users.POST("/login", h.Login)
 		users.POST("/logout", h.Logout)
+		users.POST("/token", h.IssueToken)
+		users.POST("/token/revoke", h.RevokeRefreshToken)
 		users.GET("/:id", h.GetUser)
 	}
+
+	// 当前用户接口
+	me := r.Group("/users/me", h.RequireAuth())
+	{
+		me.GET("", h.GetMe)
+		me.POST("/avatar", h.UploadAvatar)
+		me.GET("/activity", h.MyActivity)
+
+		// 账号身份、凭证与数据相关操作不接受个人访问令牌
+		account := me.Group("", h.RequireSession())
+		account.PATCH("", h.UpdateMe)
+		account.DELETE("", h.DeleteMe)
+		account.GET("/export", h.ExportMe)
+		account.POST("/password", h.ChangePassword)
+		account.GET("/tokens", h.ListTokens)
+		account.POST("/tokens", h.CreateToken)
+		account.DELETE("/tokens/:token_id", h.RevokeToken)
+	}
+
+	// 管理员接口
+	admin := r.Group("/admin", h.RequireAuth())
+	{
+		adminUsers := admin.Group("/users", h.RequirePermission(domain.PermUserManage))
+		adminUsers.GET("", h.ListUsers)
+		adminUsers.GET("/:user_id", h.GetUserDetail)
+		adminUsers.POST("/:user_id/disable", h.DisableUser)
+		adminUsers.POST("/:user_id/enable", h.EnableUser)
+		adminUsers.POST("/:user_id/password-reset", h.ForcePasswordReset)
+		adminUsers.POST("/:user_id/verify-email", h.MarkEmailVerified)
+		adminUsers.GET("/:user_id/roles", h.ListRoles)
+		adminUsers.POST("/:user_id/roles", h.GrantRole)
+		adminUsers.DELETE("/:user_id/roles/:role", h.RevokeRole)
+		adminUsers.POST("/:user_id/unlock", h.UnlockUser)
+		adminUsers.POST("/:user_id/restore", h.RestoreUser)
+
+		invitations := admin.Group("/invitations", h.RequirePermission(domain.PermUserManage))
+		invitations.GET("", h.ListInvitations)
+		invitations.POST("", h.CreateInvitation)
+		invitations.DELETE("/:invitation_id", h.RevokeInvitation)
+
+		admin.GET("/auth-events", h.RequirePermission(domain.PermAuditRead), h.ListAuthEvents)
+	}
 }
@@ -0,0 +1,111 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"mygo/internal/user/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateToken 创建个人访问令牌
+// POST /api/users/me/tokens
+func (h *Handler) CreateToken(c *gin.Context) {
+	// 路由上的 RequireSession 禁止用令牌签发令牌，避免 scope 被绕过
+	principal, _ := PrincipalFrom(c)
+
+	var req CreateTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, http.StatusBadRequest, 400, "invalid request body")
+		return
+	}
+
+	cmd := domain.CreateTokenCommand{
+		Name:      req.Name,
+		ExpiresIn: time.Duration(req.ExpiresInDays) * 24 * time.Hour,
+	}
+	for _, sc := range req.Scopes {
+		cmd.Scopes = append(cmd.Scopes, domain.Permission(sc))
+	}
+
+	token, plaintext, err := h.userService.CreateToken(requestContext(c), principal.UserID, cmd)
+	if err != nil {
+		failToken(c, err)
+		return
+	}
+
+	resp := newTokenResponse(token)
+	resp.Token = plaintext
+	success(c, resp)
+}
+
+// ListTokens 列出个人访问令牌
+// GET /api/users/me/tokens
+func (h *Handler) ListTokens(c *gin.Context) {
+	principal, _ := PrincipalFrom(c)
+
+	tokens, err := h.userService.ListTokens(requestContext(c), principal.UserID)
+	if err != nil {
+		failToken(c, err)
+		return
+	}
+
+	resp := make([]*TokenResponse, 0, len(tokens))
+	for _, t := range tokens {
+		resp = append(resp, newTokenResponse(t))
+	}
+	success(c, resp)
+}
+
+// RevokeToken 撤销个人访问令牌
+// DELETE /api/users/me/tokens/:token_id
+func (h *Handler) RevokeToken(c *gin.Context) {
+	principal, _ := PrincipalFrom(c)
+
+	tokenID, err := strconv.ParseInt(c.Param("token_id"), 10, 64)
+	if err != nil {
+		fail(c, http.StatusBadRequest, 400, "invalid token id")
+		return
+	}
+
+	if err := h.userService.RevokeToken(requestContext(c), principal.UserID, tokenID); err != nil {
+		failToken(c, err)
+		return
+	}
+
+	success(c, nil)
+}
+
+// failToken 令牌相关错误映射
+func failToken(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrTokenNotFound):
+		fail(c, http.StatusNotFound, 404, "token not found")
+	case errors.Is(err, domain.ErrInvalidScope):
+		fail(c, http.StatusBadRequest, 400, "invalid scope")
+	case errors.Is(err, domain.ErrInvalidInput):
+		fail(c, http.StatusBadRequest, 400, "invalid input")
+	default:
+		fail(c, http.StatusInternalServerError, 500, "internal server error")
+	}
+}
+
+func newTokenResponse(t *domain.PersonalAccessToken) *TokenResponse {
+	resp := &TokenResponse{
+		ID:         t.ID,
+		Name:       t.Name,
+		Prefix:     t.Prefix,
+		Scopes:     make([]string, 0, len(t.Scopes)),
+		ExpiresAt:  t.ExpiresAt,
+		LastUsedAt: t.LastUsedAt,
+		RevokedAt:  t.RevokedAt,
+		CreatedAt:  t.CreatedAt,
+	}
+	for _, sc := range t.Scopes {
+		resp.Scopes = append(resp.Scopes, string(sc))
+	}
+	return resp
+}
@@ -0,0 +1,17 @@
+-- 初始表结构，与切换到版本化迁移前 AutoMigrate 为 UserPO 生成的结构一致。
+-- 已由 AutoMigrate 建表的数据库执行 `migrate baseline 1` 标记为已执行，之后执行 `migrate up`。
+
+CREATE TABLE users (
+    id         bigserial,
+    user_id    bigint       NOT NULL,
+    username   varchar(64)  NOT NULL,
+    email      varchar(128) NOT NULL,
+    password   varchar(255) NOT NULL,
+    avatar     varchar(255),
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX idx_users_user_id ON users (user_id);
+CREATE UNIQUE INDEX idx_users_username ON users (username);
+CREATE UNIQUE INDEX idx_users_email ON users (email);
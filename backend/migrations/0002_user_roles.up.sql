@@ -0,0 +1,10 @@
+-- 用户角色，用户删除时级联删除
+CREATE TABLE user_roles (
+    id         bigserial,
+    user_id    bigint      NOT NULL,
+    role       varchar(32) NOT NULL,
+    created_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_users_roles FOREIGN KEY (user_id) REFERENCES users (user_id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX idx_user_roles_user_role ON user_roles (user_id, role);
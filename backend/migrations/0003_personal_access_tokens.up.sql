@@ -0,0 +1,17 @@
+-- 个人访问令牌，只保存 SHA-256 哈希；用户删除时级联删除
+CREATE TABLE personal_access_tokens (
+    id           bigserial,
+    user_id      bigint       NOT NULL,
+    name         varchar(64)  NOT NULL,
+    prefix       varchar(16)  NOT NULL,
+    token_hash   char(64)     NOT NULL,
+    scopes       varchar(255) NOT NULL DEFAULT '',
+    expires_at   timestamptz  NOT NULL,
+    last_used_at timestamptz,
+    revoked_at   timestamptz,
+    created_at   timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_personal_access_tokens_user FOREIGN KEY (user_id) REFERENCES users (user_id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX idx_personal_access_tokens_token_hash ON personal_access_tokens (token_hash);
+CREATE INDEX idx_personal_access_tokens_user_id ON personal_access_tokens (user_id);
@@ -0,0 +1,18 @@
+-- 认证审计事件，只追加，不设外键。user_id 为空表示所属用户已被彻底清除（记录已匿名化）
+CREATE TABLE auth_events (
+    id         bigserial,
+    user_id    bigint,
+    username   varchar(64)  NOT NULL DEFAULT '',
+    actor_id   bigint       NOT NULL DEFAULT 0,
+    type       varchar(32)  NOT NULL,
+    detail     varchar(255) NOT NULL DEFAULT '',
+    ip         varchar(64)  NOT NULL DEFAULT '',
+    user_agent varchar(512) NOT NULL DEFAULT '',
+    request_id varchar(64)  NOT NULL DEFAULT '',
+    created_at timestamptz,
+    PRIMARY KEY (id)
+);
+CREATE INDEX idx_auth_events_user_created ON auth_events (user_id, created_at);
+CREATE INDEX idx_auth_events_type ON auth_events (type);
+CREATE INDEX idx_auth_events_ip ON auth_events (ip);
+CREATE INDEX idx_auth_events_created_at ON auth_events (created_at);
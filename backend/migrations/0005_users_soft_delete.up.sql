@@ -0,0 +1,4 @@
+-- 账号注销：软删除，purge_after 之后由 worker 彻底清除
+ALTER TABLE users ADD COLUMN deleted_at timestamptz;
+ALTER TABLE users ADD COLUMN purge_after timestamptz;
+CREATE INDEX idx_users_deleted_at ON users (deleted_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS invitations;
+ALTER TABLE users DROP COLUMN IF EXISTS invited_by;
@@ -0,0 +1,19 @@
+-- 邀请注册：邀请码只保存 SHA-256 哈希，users.invited_by 记录邀请人
+ALTER TABLE users ADD COLUMN invited_by bigint NOT NULL DEFAULT 0;
+
+CREATE TABLE invitations (
+    id         bigserial,
+    prefix     varchar(16)  NOT NULL,
+    code_hash  char(64)     NOT NULL,
+    created_by bigint       NOT NULL,
+    note       varchar(255) NOT NULL DEFAULT '',
+    max_uses   bigint       NOT NULL,
+    used_count bigint       NOT NULL DEFAULT 0,
+    expires_at timestamptz  NOT NULL,
+    revoked_at timestamptz,
+    created_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_invitations_creator FOREIGN KEY (created_by) REFERENCES users (user_id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX idx_invitations_code_hash ON invitations (code_hash);
+CREATE INDEX idx_invitations_created_by ON invitations (created_by);
@@ -0,0 +1,4 @@
+-- 管理员用户管理：邮箱验证时间、停用与强制修改密码
+ALTER TABLE users ADD COLUMN email_verified_at timestamptz;
+ALTER TABLE users ADD COLUMN disabled_at timestamptz;
+ALTER TABLE users ADD COLUMN must_change_password boolean NOT NULL DEFAULT false;
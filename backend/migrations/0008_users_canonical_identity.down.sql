@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_users_email_canonical;
+DROP INDEX IF EXISTS idx_users_username_canonical;
+ALTER TABLE users DROP COLUMN IF EXISTS email_canonical;
+ALTER TABLE users DROP COLUMN IF EXISTS username_canonical;
@@ -0,0 +1,9 @@
+-- 用户名与邮箱的规范形式（NFKC + 小写），唯一性与登录查找以此为准。
+-- 规范形式依赖 Go 的规则，无法在 SQL 中可靠计算：已有用户的库在 `migrate baseline 1` 时
+-- 由 PrepareCanonicalIdentity 预先加入并回填这两列，这里对已存在的列与索引不做改动。
+-- 表中已有用户而未经回填（如回滚本迁移后重新执行）时 NOT NULL 会失败，
+-- 再次执行 `migrate baseline 1` 即可重新回填，已标记的版本不受影响。
+ALTER TABLE users ADD COLUMN IF NOT EXISTS username_canonical varchar(255) NOT NULL;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_canonical varchar(255) NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_username_canonical ON users (username_canonical);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_canonical ON users (email_canonical);
@@ -0,0 +1,12 @@
+// Package migrations 内嵌版本化的 SQL 迁移文件。
+//
+// 文件名格式为 NNNN_name.up.sql / NNNN_name.down.sql，版本号递增且不可修改已发布的文件，
+// 使用 `go run ./cmd/migrate create <name>` 生成新文件。
+package migrations
+
+import "embed"
+
+// FS 全部迁移文件
+//
+//go:embed *.sql
+var FS embed.FS